@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"fn-gen/internal/cli"
+)
+
+// runEnumerate walks the full combination space of the configured pattern.
+// The total count goes to stderr first so stdout stays a clean name list,
+// e.g. for building a lookup table:
+//
+//	fn-gen enumerate -mode minimal -offset 100 -limit 50
+func runEnumerate(ctx context.Context, cfg cli.Config) error {
+	if cfg.Backronym != "" {
+		return errors.New("-backronym only applies to generating names")
+	}
+	gen, err := newGenerator(cfg)
+	if err != nil {
+		return err
+	}
+
+	total, err := gen.Combinations()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "total: %d\n", total)
+
+	out := bufio.NewWriterSize(os.Stdout, 64*1024)
+	for name, err := range gen.Enumerate(ctx, cfg.Offset, cfg.Limit) {
+		if err != nil {
+			_ = out.Flush()
+			return err
+		}
+		fmt.Fprintln(out, name)
+	}
+	return out.Flush()
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"fn-gen/internal/cli"
+	"fn-gen/internal/words"
+)
+
+// runList prints every bundled word pack with its header, or, given a pack
+// as "{lang}/{mode}" or a path to a .json file, that pack's effective word
+// lists after resolving its extends chain.
+func runList(cfg cli.Config) error {
+	if len(cfg.Args) > 0 {
+		return listPack(cfg.Args[0])
+	}
+
+	packs, err := words.List()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "PACK\tNAME\tSCHEMA\tLICENSE\tDESCRIPTION")
+	for _, p := range packs {
+		id := p.Lang + "/" + p.Mode
+		if p.Err != nil {
+			fmt.Fprintf(w, "%s\t-\t-\t-\tinvalid: %v\n", id, p.Err)
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\tv%d\t%s\t%s\n",
+			id, p.Meta.Name, p.Meta.SchemaVersion, orDash(p.Meta.License), p.Meta.Description)
+	}
+	return w.Flush()
+}
+
+// listPack prints the extends chain of a single pack and every word of its
+// effective lists together with the pack that supplied it. A bundled pack
+// "{lang}/{mode}" is loaded like -lang and -mode would, falling back to
+// other languages for missing modes and categories.
+func listPack(ref string) error {
+	var ws words.WordSet
+	var err error
+	if lang, mode, ok := strings.Cut(ref, "/"); ok && !strings.HasSuffix(ref, ".json") {
+		ws, err = words.Load(lang, mode)
+	} else {
+		ws, err = words.LoadFile(ref)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("pack: %s\n", ws.Meta.Name)
+	fmt.Printf("chain: %s\n", strings.Join(ws.Chain, " → "))
+
+	// A language without this mode uses another language's pack whole,
+	// e.g. fr/codename is English
+	if lang, mode, _ := strings.Cut(ws.Chain[0], "/"); ws.Lang != "" && lang != ws.Lang {
+		fmt.Printf("note: %s has no %s pack, so its words come from %s\n", ws.Lang, mode, lang)
+	}
+	if len(ws.Fallback) > 0 {
+		fmt.Printf("fallback: %s\n", strings.Join(ws.Fallback, ", "))
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	for _, key := range words.Categories() {
+		list := ws.Get(key)
+		fmt.Fprintf(w, "\n%s (%d)\t\n", key, len(list))
+		for _, word := range list {
+			o := ws.Origin(key, word)
+			fmt.Fprintf(w, "  %s\t%s (%s)\n", word, o.Pack, orDash(o.Lang))
+		}
+	}
+	return w.Flush()
+}
+
+// orDash substitutes "-" for empty table cells.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
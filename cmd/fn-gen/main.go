@@ -1,11 +1,19 @@
 package main
 
 import (
+	"bufio"
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"slices"
+	"strings"
 
+	"fn-gen/internal/blocklist"
 	"fn-gen/internal/cli"
 	"fn-gen/internal/generator"
+	"fn-gen/internal/grammar"
 	"fn-gen/internal/words"
 )
 
@@ -13,44 +21,281 @@ func main() {
 	// Parse command-line flags to get configuration
 	cfg := cli.ParseFlags()
 
+	// Stop long runs promptly on Ctrl-C; output written so far stays intact
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var err error
+	switch cfg.Command {
+	case "", "generate":
+		err = runGenerate(ctx, cfg)
+	case "enumerate":
+		err = runEnumerate(ctx, cfg)
+	case "stats":
+		err = runStats(ctx, cfg)
+	case "validate":
+		err = runValidate(cfg)
+	case "claim":
+		err = runClaim(cfg)
+	case "release":
+		err = runRelease(cfg)
+	case "lookup":
+		err = runLookup(cfg)
+	case "list":
+		err = runList(cfg)
+	case "pick":
+		err = runPick(cfg)
+	default:
+		err = fmt.Errorf("unknown command %q", cfg.Command)
+	}
+	if err != nil {
+		exit(err)
+	}
+}
+
+// newGenerator loads the word set for the configured language and mode
+// and wraps it in a Generator. opts are applied after the ones derived
+// from cfg.
+func newGenerator(cfg cli.Config, extra ...generator.Option) (*generator.Generator, error) {
+	// An explicit pattern may only name known categories, optionally
+	// qualified with a language ("de:adjectives"). Tags are put in
+	// canonical case, since the pattern keys the seed stream
+	cfg.Pattern = slices.Clone(cfg.Pattern)
+	qualified := make(map[string][]string) // Language → categories it supplies
+	for i, key := range cfg.Pattern {
+		category := key
+		if lang, c, ok := strings.Cut(key, ":"); ok {
+			tag, err := words.ParseTag(lang)
+			if err != nil {
+				return nil, fmt.Errorf("pattern %q: %w", key, err)
+			}
+			category, cfg.Pattern[i] = c, tag+":"+c
+			qualified[tag] = append(qualified[tag], c)
+		}
+		if !slices.Contains(words.Categories(), category) {
+			return nil, fmt.Errorf("unknown category %q in pattern (valid: %v)", category, words.Categories())
+		}
+	}
+
+	if cfg.Join != "" && !slices.Contains(grammar.Joins(), grammar.Join(cfg.Join)) {
+		return nil, fmt.Errorf("unknown join %q (valid: %v)", cfg.Join, grammar.Joins())
+	}
+	if cfg.Case != "" && !slices.Contains(grammar.Cases(), grammar.Case(cfg.Case)) {
+		return nil, fmt.Errorf("unknown case %q (valid: %v)", cfg.Case, grammar.Cases())
+	}
+
+	// A backronym's letters decide the initials, which the initial-based
+	// constraints would contradict
+	if cfg.Backronym != "" && (cfg.Alliterate != "" || cfg.StartsWith != "" || cfg.Pronounceable || cfg.AcronymDict != "") {
+		return nil, errors.New("-backronym cannot be combined with -alliterate, -starts-with, -pronounceable or -acronym-dict")
+	}
+	if cfg.Alliterate != "" && cfg.AcronymDict != "" {
+		return nil, errors.New("-alliterate cannot be combined with -acronym-dict")
+	}
+	if cfg.Backronym != "" && len(cfg.Fix) > 0 {
+		return nil, errors.New("-backronym cannot be combined with -fix")
+	}
+	if cfg.Sequence > 0 && (cfg.StartsWith != "" || cfg.Backronym != "") {
+		return nil, errors.New("-sequence cannot be combined with -starts-with or -backronym")
+	}
+	if cfg.Sequence < 0 {
+		return nil, fmt.Errorf("-sequence must be positive, got %d", cfg.Sequence)
+	}
+
+	// The unqualified categories the pattern draws from must exist in the
+	// word file
+	pattern := cfg.Pattern
+	if len(pattern) == 0 {
+		pattern = generator.Pattern(generator.Mode(cfg.Mode))
+	}
+	pattern = slices.DeleteFunc(slices.Clone(pattern), func(key string) bool { return strings.Contains(key, ":") })
+
 	// Load the word set for the specified language and mode
-	// Each language/mode combination has its own JSON file with word pools
-	wordSet, err := words.Load(cfg.Lang, cfg.Mode)
+	// Each language/mode combination has its own JSON file with word pools;
+	// -pack swaps in a custom file, resolving whatever it extends
+	var wordSet words.WordSet
+	var err error
+	if cfg.Pack != "" {
+		wordSet, err = words.LoadFile(cfg.Pack, pattern...)
+	} else {
+		wordSet, err = words.Load(cfg.Lang, cfg.Mode, pattern...)
+	}
 	if err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		return nil, err
+	}
+
+	// Qualified categories come from the same mode in their language
+	var opts []generator.Option
+	if len(qualified) > 0 {
+		packs := make(map[string]words.WordSet, len(qualified))
+		for tag, categories := range qualified {
+			if packs[tag], err = words.Load(tag, cfg.Mode, categories...); err != nil {
+				return nil, err
+			}
+		}
+		opts = append(opts, generator.WithLanguages(packs))
+	}
+
+	// Collect the blocklists; the bundled trademark list goes first
+	paths := cfg.Blocklists
+	if cfg.Trademarks {
+		paths = append([]string{blocklist.TrademarksFile}, paths...)
+	}
+	if len(paths) > 0 {
+		list, err := blocklist.Load(paths...)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, generator.WithBlocklist(list))
+	}
+
+	// Names already in use are skipped the same way
+	if cfg.Exclude != "" {
+		used, err := blocklist.LoadExcludes(cfg.Exclude)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, generator.WithExclude(used))
+	}
+
+	// Acronyms must spell a word of the dictionary
+	if cfg.AcronymDict != "" {
+		dict, err := words.LoadDictionary(cfg.AcronymDict)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, generator.WithDictionary(dict))
 	}
 
 	// Initialize the generator with the loaded words and configuration
-	gen := generator.New(wordSet, cfg)
+	return generator.New(wordSet, cfg, append(opts, extra...)...), nil
+}
 
-	// Generate the requested number of names
+// runGenerate prints cfg.Count names, either plain or with explanations.
+func runGenerate(ctx context.Context, cfg cli.Config) error {
+	// With a registry, claimed names are only issued for their own seeds
+	var opts []generator.Option
+	if cfg.Registry != "" {
+		claims, err := loadClaims(cfg.Registry)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, generator.WithRegistry(claims))
+	}
+
+	gen, err := newGenerator(cfg, opts...)
+	if err != nil {
+		return err
+	}
+	defer reportSkipped(gen, cfg)
+
+	if !cfg.Explain {
+		// Standard mode: stream names through a buffered writer.
+		// Names are generated in parallel but printed in index order,
+		// so large -count values stay fast with bounded memory.
+		out := bufio.NewWriterSize(os.Stdout, 64*1024)
+		err := gen.WriteBulk(ctx, out, 0, cfg.Count, cfg.Workers)
+		if flushErr := out.Flush(); err == nil {
+			err = flushErr
+		}
+		return err
+	}
+
+	// Explain mode: show detailed breakdown of how each name was generated
 	// Each iteration uses a unique index for seed differentiation
-	for i := 0; i < cfg.Count; i++ {
-		if cfg.Explain {
-			// Explain mode: show detailed breakdown of how the name was generated
-			result := gen.GenerateExplained(i)
-
-			fmt.Println(result.Name)
-			fmt.Println("— explanation —")
-			fmt.Printf("seed: %s\n", result.Seed)
-			fmt.Printf("pattern: %v\n", result.Pattern)
-
-			// Print details for each word part showing the hash calculation
-			for _, p := range result.Parts {
-				fmt.Printf(
-					"- %s: %q (hash=%d index=%d/%d)\n",
-					p.Category,
-					p.Word,
-					p.Hash,
-					p.Index,
-					p.ListSize,
-				)
+	for result, err := range gen.ExplainN(ctx, 0, cfg.Count) {
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(gen.Line(result))
+		fmt.Println("— explanation —")
+		fmt.Printf("seed: %s\n", result.Seed)
+		fmt.Printf("pattern: %v\n", result.Pattern)
+		if result.Claimed {
+			fmt.Println("claimed: registry entry for this seed")
+		}
+		if fixed := fixedParts(result); len(fixed) > 0 {
+			fmt.Printf("fixed: %s\n", strings.Join(fixed, ", "))
+		}
+		if result.Initial != "" {
+			fmt.Printf("sequence: %d → %s", cfg.Sequence, result.Initial)
+			if skipped := gen.SkippedLetters(); len(skipped) > 0 {
+				fmt.Printf(" (no names start with %s)", strings.Join(skipped, ", "))
 			}
 			fmt.Println()
-		} else {
-			// Standard mode: just print the generated name
-			fmt.Println(gen.Generate(i))
 		}
+
+		// Candidates discarded before this name, e.g. by the blocklist
+		for _, r := range result.Rejected {
+			fmt.Printf("rejected: %q (%s)\n", r.Name, r.Reason)
+		}
+
+		// Print details for each word part showing the drawn value
+		// and the pack the word came from, noting language fallbacks.
+		// Backronym parts lead with their letter and note when the
+		// pattern cycle's category had no word for it. Words pinned by
+		// -fix were not drawn, so they only show as fixed
+		for _, p := range result.Parts {
+			if p.Locked {
+				fmt.Printf("- %s: %q (fixed)\n", p.Category, p.Word)
+				continue
+			}
+			label := p.Category
+			if p.Letter != "" {
+				label = p.Letter + " → " + p.Category
+				if p.Cycled != "" {
+					label += fmt.Sprintf(" (no %s word starts with %s)", p.Cycled, p.Letter)
+				}
+			}
+			fallback := ""
+			if p.FallbackFor != "" {
+				fallback = ", fallback for " + p.FallbackFor
+			}
+			fmt.Printf(
+				"- %s: %q (hash=%d index=%d/%d) from %s%s\n",
+				label,
+				p.Word,
+				p.Hash,
+				p.Index,
+				p.ListSize,
+				p.Origin,
+				fallback,
+			)
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+// fixedParts returns the words of result pinned by -fix, as
+// "category=Word".
+func fixedParts(result generator.ExplainedResult) []string {
+	var fixed []string
+	for _, p := range result.Parts {
+		if p.Locked {
+			fixed = append(fixed, p.Category+"="+p.Word)
+		}
+	}
+	return fixed
+}
+
+// reportSkipped tells on stderr how many candidates the exclude file
+// turned away, so stdout stays a clean list of names.
+func reportSkipped(gen *generator.Generator, cfg cli.Config) {
+	if cfg.Exclude == "" {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "skipped %d already-used name(s)\n", gen.Rejections(generator.Excluded))
+}
+
+// exit reports err on stderr and terminates. An interrupted run exits with
+// the conventional 130 (128 + SIGINT) instead of a generic failure.
+func exit(err error) {
+	if errors.Is(err, context.Canceled) {
+		fmt.Fprintln(os.Stderr, "interrupted")
+		os.Exit(130)
 	}
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
 }
@@ -0,0 +1,422 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"fn-gen/internal/cli"
+	"fn-gen/internal/generator"
+	"fn-gen/internal/grammar"
+	"fn-gen/internal/words"
+)
+
+// pickHelp lists the keys of the picker.
+const pickHelp = "↑/↓ select · 1-9 lock/unlock word · r re-roll · n new page · m mode · l language · c case · enter pick · q quit"
+
+// picker is the state of the interactive picker: a page of candidates,
+// each derived from its own seed, with some of their words locked.
+type picker struct {
+	cfg    cli.Config           // Current mode, language and case
+	gen    *generator.Generator // Generator for cfg
+	modes  []string             // Modes m cycles through
+	langs  []string             // Bundled languages l cycles through
+	base   string               // Seeds are base-1, base-2, ...
+	next   int                  // Number of the last seed handed out
+	rows   []pickRow            // The page of candidates
+	cursor int                  // Selected row
+	status string               // Message under the page, e.g. why a switch failed
+}
+
+// pickRow is one candidate of the page.
+type pickRow struct {
+	seed   string                    // Seed the free words are derived from
+	locks  map[int]string            // Locked words by pattern position
+	result generator.ExplainedResult // The candidate as derived
+	err    error                     // Why no candidate could be derived
+}
+
+// runPick shows a page of candidates on the terminal and lets the user
+// re-roll them, lock words, and switch mode, language and case until one
+// is picked. The page is drawn on stderr; the picked name, with the seed
+// and pattern that reproduce it, is printed on stdout.
+func runPick(cfg cli.Config) error {
+	// A backronym's words are spelt by its letters, not by positions the
+	// picker could lock
+	if cfg.Backronym != "" {
+		return errors.New("-backronym only applies to generating names")
+	}
+
+	p, err := newPicker(cfg)
+	if err != nil {
+		return err
+	}
+
+	restore, err := rawTerminal(os.Stdin)
+	if err != nil {
+		return err
+	}
+	// Draw on the alternate screen, so the shell's screen is left intact
+	fmt.Fprint(os.Stderr, "\x1b[?1049h\x1b[?25l")
+	picked, err := p.loop()
+	fmt.Fprint(os.Stderr, "\x1b[?25h\x1b[?1049l")
+	restore()
+	if err != nil || !picked {
+		return err
+	}
+
+	p.printChoice()
+	return nil
+}
+
+// newPicker prepares the first page for cfg. Pages hold -count
+// candidates, or 10 if -count is 1.
+func newPicker(cfg cli.Config) (*picker, error) {
+	packs, err := words.List()
+	if err != nil {
+		return nil, err
+	}
+	p := &picker{cfg: cfg, base: cfg.Seed}
+	for _, pack := range packs {
+		if !slices.Contains(p.langs, pack.Lang) {
+			p.langs = append(p.langs, pack.Lang)
+		}
+	}
+	for _, mode := range generator.Modes() {
+		p.modes = append(p.modes, string(mode))
+	}
+	if p.base == "" {
+		// Like automatic seeds, the page is reproducible within the day
+		p.base = "pick-" + time.Now().Format("2006-01-02")
+	}
+
+	if p.gen, err = newGenerator(p.cfg); err != nil {
+		return nil, err
+	}
+	size := cfg.Count
+	if size <= 1 {
+		size = 10
+	}
+	p.rows = make([]pickRow, size)
+	p.newPage()
+	return p, nil
+}
+
+// loop draws the page and handles keys until a candidate is picked
+// (true) or the user quits (false).
+func (p *picker) loop() (bool, error) {
+	buf := make([]byte, 16)
+	for {
+		p.draw()
+		n, err := os.Stdin.Read(buf)
+		if err != nil {
+			return false, err
+		}
+		p.status = ""
+
+		switch key := string(buf[:n]); key {
+		case "\x1b[A", "k":
+			p.cursor = (p.cursor + len(p.rows) - 1) % len(p.rows)
+		case "\x1b[B", "j":
+			p.cursor = (p.cursor + 1) % len(p.rows)
+		case "r":
+			row := &p.rows[p.cursor]
+			row.seed = p.seed()
+			p.derive(row)
+		case "n":
+			p.newPage()
+		case "m":
+			p.switchTo(func(cfg *cli.Config) { cfg.Mode = cycle(p.modes, cfg.Mode) }, true)
+		case "l":
+			p.switchTo(func(cfg *cli.Config) { cfg.Lang = cycle(p.langs, cfg.Lang) }, true)
+		case "c":
+			p.switchTo(func(cfg *cli.Config) { cfg.Case = string(cycle(grammar.Cases(), grammar.Case(cfg.Case))) }, false)
+		case "\r", "\n":
+			if p.rows[p.cursor].err == nil {
+				return true, nil
+			}
+			p.status = "this candidate has no name; re-roll it first"
+		case "q", "\x1b", "\x03":
+			return false, nil
+		default:
+			if len(key) == 1 && key[0] >= '1' && key[0] <= '9' {
+				p.toggleLock(int(key[0] - '1'))
+			}
+		}
+	}
+}
+
+// seed hands out the next unused seed.
+func (p *picker) seed() string {
+	p.next++
+	return fmt.Sprintf("%s-%d", p.base, p.next)
+}
+
+// newPage replaces every candidate by one from a fresh seed.
+func (p *picker) newPage() {
+	for i := range p.rows {
+		p.rows[i] = pickRow{seed: p.seed()}
+		p.derive(&p.rows[i])
+	}
+}
+
+// derive derives row's candidate from its seed and locks.
+func (p *picker) derive(row *pickRow) {
+	row.result, row.err = p.gen.Pick(row.seed, row.locks)
+}
+
+// switchTo applies change to the configuration and derives the page
+// again from the same seeds. Locks, and words fixed with -fix, are
+// dropped if unlock is set, as the pattern or the words change. If the
+// new configuration cannot generate, the old one stays and the reason is
+// shown.
+func (p *picker) switchTo(change func(*cli.Config), unlock bool) {
+	cfg := p.cfg
+	change(&cfg)
+	if unlock {
+		cfg.Fix = nil
+	}
+	gen, err := newGenerator(cfg)
+	if err != nil {
+		p.status = err.Error()
+		return
+	}
+
+	p.cfg, p.gen = cfg, gen
+	for i := range p.rows {
+		if unlock {
+			p.rows[i].locks = nil
+		}
+		p.derive(&p.rows[i])
+	}
+}
+
+// toggleLock locks the selected candidate's word number n (counting
+// from 0 as displayed), or unlocks it if it is locked.
+func (p *picker) toggleLock(n int) {
+	row := &p.rows[p.cursor]
+	positions := partPositions(row.result)
+	if row.err != nil || n >= len(positions) {
+		return
+	}
+
+	pos := positions[n]
+	if _, locked := row.locks[pos]; locked {
+		delete(row.locks, pos)
+		p.derive(row)
+		return
+	}
+	if row.locks == nil {
+		row.locks = make(map[int]string)
+	}
+	row.locks[pos] = row.result.Parts[n].Word
+
+	// A lock that leaves no acceptable name, e.g. under -alliterate, is
+	// taken back rather than leaving the row without words to unlock
+	kept := *row
+	if p.derive(row); row.err != nil {
+		delete(kept.locks, pos)
+		*row = kept
+		p.status = row.err.Error()
+	}
+}
+
+// partPositions returns the pattern position of every part of result.
+// Parts follow the pattern, skipping empty categories.
+func partPositions(result generator.ExplainedResult) []int {
+	positions := make([]int, 0, len(result.Parts))
+	pos := 0
+	for _, part := range result.Parts {
+		for pos < len(result.Pattern) && result.Pattern[pos] != part.Category {
+			pos++
+		}
+		positions = append(positions, pos)
+		pos++
+	}
+	return positions
+}
+
+// draw redraws the whole page on stderr.
+func (p *picker) draw() {
+	var b strings.Builder
+	b.WriteString("\x1b[H\x1b[2J")
+	casing := grammar.Case(p.cfg.Case)
+	if casing == "" {
+		casing = grammar.CaseTitle
+	}
+	fmt.Fprintf(&b, "fn-gen pick · mode %s · lang %s · case %s\n\n", p.cfg.Mode, p.cfg.Lang, casing)
+
+	for i, row := range p.rows {
+		text := p.gen.Line(row.result)
+		if row.err != nil {
+			text = "error: " + row.err.Error()
+		}
+		line := fmt.Sprintf("  %-50s \x1b[2m%s\x1b[22m", text, row.seed)
+		if i == p.cursor {
+			line = "\x1b[7m›" + line[1:] + "\x1b[27m"
+		}
+		b.WriteString(line + "\n")
+	}
+
+	// The words of the selected candidate, by the number that locks them
+	b.WriteString("\n")
+	if row := p.rows[p.cursor]; row.err == nil {
+		for i, part := range row.result.Parts {
+			lock := ""
+			if part.Locked {
+				lock = "  \x1b[1mlocked\x1b[22m"
+			}
+			fmt.Fprintf(&b, "  %d  %-12s %s%s\n", i+1, part.Category, part.Word, lock)
+		}
+	}
+
+	fmt.Fprintf(&b, "\n%s\n", pickHelp)
+	if p.status != "" {
+		fmt.Fprintf(&b, "\n%s\n", p.status)
+	}
+	fmt.Fprint(os.Stderr, b.String())
+}
+
+// printChoice prints the picked candidate with the seed and pattern that
+// reproduce it.
+func (p *picker) printChoice() {
+	row := p.rows[p.cursor]
+	fmt.Println(p.gen.Line(row.result))
+	fmt.Printf("seed: %s\n", row.seed)
+	fmt.Printf("pattern: %v\n", row.result.Pattern)
+
+	// Locked words, and those fixed with -fix, are reproduced by -fix
+	fixes := fixEntries(row.result)
+	if len(fixes) > 0 {
+		fmt.Printf("fixed: %s\n", strings.Join(fixes, ", "))
+	}
+
+	args := reproduceArgs(p.cfg, row.seed, fixes)
+	for i, arg := range args {
+		args[i] = shellQuote(arg)
+	}
+	fmt.Printf("reproduce: %s\n", strings.Join(args, " "))
+}
+
+// reproduceArgs returns the command line that generates the name of seed
+// under cfg, with the words of fixes pinned: every flag that changes which
+// name a seed gives, or how it is printed, that differs from its default.
+func reproduceArgs(cfg cli.Config, seed string, fixes []string) []string {
+	args := []string{"fn-gen", "-mode", cfg.Mode, "-lang", cfg.Lang}
+	if cfg.Pack != "" {
+		args = append(args, "-pack", cfg.Pack)
+	}
+	if len(cfg.Pattern) > 0 {
+		args = append(args, "-pattern", strings.Join(cfg.Pattern, ","))
+	}
+	if cfg.Join != "" && cfg.Join != string(grammar.JoinSpaced) {
+		args = append(args, "-join", cfg.Join)
+	}
+	if cfg.Case != "" && cfg.Case != string(grammar.CaseTitle) {
+		args = append(args, "-case", cfg.Case)
+	}
+
+	// Constraints
+	if cfg.Alliterate != "" {
+		args = append(args, "-alliterate="+cfg.Alliterate)
+	}
+	if cfg.StartsWith != "" {
+		args = append(args, "-starts-with", cfg.StartsWith)
+	}
+	if cfg.Sequence > 0 {
+		args = append(args, "-sequence", strconv.Itoa(cfg.Sequence))
+		if cfg.Alphabet != "" {
+			args = append(args, "-alphabet", cfg.Alphabet)
+		}
+	}
+	for _, limit := range []struct {
+		flag  string
+		value int
+	}{
+		{"-max-syllables", cfg.MaxSyllables},
+		{"-min-length", cfg.MinLength},
+		{"-max-length", cfg.MaxLength},
+	} {
+		if limit.value > 0 {
+			args = append(args, limit.flag, strconv.Itoa(limit.value))
+		}
+	}
+
+	// Acronyms
+	if cfg.Acronym {
+		args = append(args, "-acronym")
+	}
+	if cfg.Pronounceable {
+		args = append(args, "-pronounceable")
+	}
+	if cfg.AcronymDict != "" {
+		args = append(args, "-acronym-dict", cfg.AcronymDict)
+	}
+
+	// Names that are replaced
+	for _, path := range cfg.Blocklists {
+		args = append(args, "-blocklist", path)
+	}
+	if cfg.Trademarks {
+		args = append(args, "-trademarks")
+	}
+	if cfg.Exclude != "" {
+		args = append(args, "-exclude-file", cfg.Exclude)
+	}
+
+	args = append(args, "-seed", seed)
+	for _, fix := range fixes {
+		args = append(args, "-fix", fix)
+	}
+	return args
+}
+
+// fixEntries returns the -fix entries that pin the locked words of
+// result: "category=Word", or "position=Word" for a category the pattern
+// repeats, so that the entry pins the same position.
+func fixEntries(result generator.ExplainedResult) []string {
+	var entries []string
+	for i, pos := range partPositions(result) {
+		part := result.Parts[i]
+		if !part.Locked {
+			continue
+		}
+		key := part.Category
+		if countOf(result.Pattern, key) > 1 {
+			key = strconv.Itoa(pos + 1)
+		}
+		entries = append(entries, key+"="+part.Word)
+	}
+	return entries
+}
+
+// countOf returns how often key occurs in pattern.
+func countOf(pattern []string, key string) int {
+	n := 0
+	for _, k := range pattern {
+		if k == key {
+			n++
+		}
+	}
+	return n
+}
+
+// cycle returns the value after current in values, wrapping around; the
+// first value if current is not among them.
+func cycle[T comparable](values []T, current T) T {
+	return values[(slices.Index(values, current)+1)%len(values)]
+}
+
+// shellQuote quotes s for a POSIX shell unless it is plain.
+func shellQuote(s string) string {
+	if s != "" && !strings.ContainsFunc(s, func(r rune) bool {
+		return !strings.ContainsRune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_.,:/=", r)
+	}) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
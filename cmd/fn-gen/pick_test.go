@@ -0,0 +1,87 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"fn-gen/internal/cli"
+)
+
+// writeFile stores content as name in dir, creating the directories name
+// needs, and returns its path, like writeFile of the words tests.
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("cannot write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestPick_ReproduceArgs(t *testing.T) {
+	t.Chdir(filepath.Join("..", ".."))
+	dir := t.TempDir()
+	blocklist := writeFile(t, dir, "block.txt", "re: (?i)^s\n")
+	exclude := writeFile(t, dir, "used.txt", "Agile Alpaca\n")
+	dict := writeFile(t, dir, "dict.txt", "ash\ncsh\nmsh\nsih\nsph\n")
+
+	tests := []struct {
+		name string
+		cfg  cli.Config
+	}{
+		{"compound", cli.Config{
+			Lang: "de", Mode: "startup", Seed: "t", Join: "compound", Case: "kebab",
+			Sequence: 3, MaxLength: 60, Blocklists: []string{blocklist}, Trademarks: true, Acronym: true,
+		}},
+		{"alliteration", cli.Config{
+			Lang: "en", Mode: "codename", Seed: "t", Alliterate: "sound", Pronounceable: true,
+			MaxSyllables: 7, MinLength: 5, Exclude: exclude, Sequence: 2, Alphabet: "AEIOU",
+		}},
+		{"dictionary", cli.Config{
+			Lang: "en", Mode: "startup", Seed: "t", AcronymDict: dict, StartsWith: "s",
+			Pattern: []string{"adjectives", "core", "suffix"}, Fix: []string{"suffix=Hub"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := newPicker(tt.cfg)
+			if err != nil {
+				t.Fatalf("newPicker error: %v", err)
+			}
+
+			// Lock the first word of a candidate and re-roll the rest
+			p.cursor = 1
+			p.toggleLock(0)
+			row := &p.rows[p.cursor]
+			row.seed = p.seed()
+			p.derive(row)
+			if row.err != nil {
+				t.Fatalf("derive error: %v", row.err)
+			}
+			want := p.gen.Line(row.result)
+
+			args := reproduceArgs(p.cfg, row.seed, fixEntries(row.result))
+			cfg, err := cli.Parse(flag.NewFlagSet("fn-gen", flag.ContinueOnError), args[1:])
+			if err != nil {
+				t.Fatalf("cannot parse %v: %v", args, err)
+			}
+			gen, err := newGenerator(cfg)
+			if err != nil {
+				t.Fatalf("newGenerator(%v) error: %v", args, err)
+			}
+			result, err := gen.GenerateExplained(0)
+			if err != nil {
+				t.Fatalf("%v: %v", args, err)
+			}
+			if got := gen.Line(result); got != want {
+				t.Errorf("%s gives %q, want the picked %q", strings.Join(args, " "), got, want)
+			}
+		})
+	}
+}
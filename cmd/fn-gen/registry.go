@@ -0,0 +1,144 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"fn-gen/internal/cli"
+	"fn-gen/internal/generator"
+	"fn-gen/internal/registry"
+)
+
+// openRegistry returns the configured registry, or the default one.
+func openRegistry(cfg cli.Config) *registry.Store {
+	path := cfg.Registry
+	if path == "" {
+		path = registry.DefaultPath
+	}
+	return registry.Open(path)
+}
+
+// loadClaims reads a snapshot of the registry at path for generate.
+func loadClaims(path string) (*registry.Data, error) {
+	var claims *registry.Data
+	err := registry.Open(path).View(func(d *registry.Data) error {
+		claims = d
+		return nil
+	})
+	return claims, err
+}
+
+// runClaim generates the name for -seed and records it in the registry,
+// skipping names other seeds have claimed. Claiming a seed again prints
+// its existing name, so CI jobs can claim unconditionally:
+//
+//	fn-gen claim -seed JIRA-1234
+func runClaim(cfg cli.Config) error {
+	if cfg.Seed == "" {
+		return errors.New("claim requires -seed (e.g. a ticket ID)")
+	}
+
+	store := openRegistry(cfg)
+	var claim registry.Claim
+	var existing bool
+	err := store.Update(func(d *registry.Data) error {
+		if claim, existing = d.BySeed(cfg.Seed); existing {
+			return nil
+		}
+
+		// Generate under the lock, so no other process can claim the
+		// same name in between
+		gen, err := newGenerator(cfg, generator.WithRegistry(d))
+		if err != nil {
+			return err
+		}
+		name, err := gen.Generate(0)
+		if err != nil {
+			return err
+		}
+
+		claim = registry.Claim{
+			Name:      name,
+			Seed:      cfg.Seed,
+			Lang:      cfg.Lang,
+			Mode:      cfg.Mode,
+			ClaimedAt: time.Now().UTC().Truncate(time.Second),
+		}
+		return d.Add(claim)
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(claim.Name)
+	if existing {
+		fmt.Fprintf(os.Stderr, "seed %q already claimed this name on %s\n", claim.Seed, claim.ClaimedAt.Format(time.DateOnly))
+	} else {
+		fmt.Fprintf(os.Stderr, "claimed for seed %q in %s\n", claim.Seed, store.Path())
+	}
+	return nil
+}
+
+// runRelease removes a claim by -seed or by name, making the name
+// available to other seeds again:
+//
+//	fn-gen release -seed JIRA-1234
+//	fn-gen release "Smart Data Hub"
+func runRelease(cfg cli.Config) error {
+	var claim registry.Claim
+	err := openRegistry(cfg).Update(func(d *registry.Data) error {
+		var err error
+		switch {
+		case cfg.Seed != "":
+			claim, err = d.ReleaseSeed(cfg.Seed)
+		case len(cfg.Args) > 0:
+			claim, err = d.ReleaseName(cfg.Args[0])
+		default:
+			err = errors.New("release requires -seed or a name")
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("released %q (seed %q)\n", claim.Name, claim.Seed)
+	return nil
+}
+
+// runLookup prints the claim for -seed or for a name:
+//
+//	fn-gen lookup -seed JIRA-1234
+//	fn-gen lookup "smart data hub"
+func runLookup(cfg cli.Config) error {
+	var claim registry.Claim
+	err := openRegistry(cfg).View(func(d *registry.Data) error {
+		var ok bool
+		switch {
+		case cfg.Seed != "":
+			if claim, ok = d.BySeed(cfg.Seed); !ok {
+				return fmt.Errorf("seed %q: %w", cfg.Seed, registry.ErrNotFound)
+			}
+		case len(cfg.Args) > 0:
+			if claim, ok = d.ByName(cfg.Args[0]); !ok {
+				return fmt.Errorf("name %q: %w", cfg.Args[0], registry.ErrNotFound)
+			}
+		default:
+			return errors.New("lookup requires -seed or a name")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "name:\t%s\n", claim.Name)
+	fmt.Fprintf(w, "seed:\t%s\n", claim.Seed)
+	fmt.Fprintf(w, "pack:\t%s/%s\n", claim.Lang, claim.Mode)
+	fmt.Fprintf(w, "claimed:\t%s\n", claim.ClaimedAt.Format(time.RFC3339))
+	return w.Flush()
+}
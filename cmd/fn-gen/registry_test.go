@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"fn-gen/internal/blocklist"
+	"fn-gen/internal/cli"
+	"fn-gen/internal/generator"
+	"fn-gen/internal/registry"
+)
+
+func TestClaim_CasedNameIsTakenInEveryCase(t *testing.T) {
+	t.Chdir(filepath.Join("..", ".."))
+	path := filepath.Join(t.TempDir(), "registry.json")
+	cfg := cli.Config{Lang: "en", Mode: "minimal", Case: "pascal", Count: 1, Seed: "A", Registry: path}
+	if err := runClaim(cfg); err != nil {
+		t.Fatalf("claim error: %v", err)
+	}
+	claims, err := loadClaims(path)
+	if err != nil {
+		t.Fatalf("cannot load registry: %v", err)
+	}
+	taken, _ := claims.ClaimedName("A")
+
+	// Find another seed whose name is the claimed one without a registry
+	generate := func(cfg cli.Config, opts ...generator.Option) string {
+		t.Helper()
+		gen, err := newGenerator(cfg, opts...)
+		if err != nil {
+			t.Fatalf("newGenerator error: %v", err)
+		}
+		name, err := gen.Generate(0)
+		if err != nil {
+			t.Fatalf("Generate error: %v", err)
+		}
+		return name
+	}
+	seed := ""
+	for i := range 5000 {
+		s := fmt.Sprintf("S%d", i)
+		if generate(cli.Config{Lang: "en", Mode: "minimal", Case: "pascal", Count: 1, Seed: s}) == taken {
+			seed = s
+			break
+		}
+	}
+	if seed == "" {
+		t.Fatalf("no seed draws %q", taken)
+	}
+
+	// With the registry, that seed moves on in every case
+	for _, c := range []string{"title", "pascal", "kebab", "camel"} {
+		name := generate(cli.Config{Lang: "en", Mode: "minimal", Case: c, Count: 1, Seed: seed}, generator.WithRegistry(claims))
+		if blocklist.Key(name) == blocklist.Key(taken) {
+			t.Errorf("-case %s: seed %q got %q, claimed by seed A", c, seed, name)
+		}
+	}
+
+	// Claiming it records another name instead of failing
+	cfg.Seed = seed
+	if err := runClaim(cfg); err != nil {
+		t.Fatalf("claim %q error: %v", seed, err)
+	}
+	err = registry.Open(path).View(func(d *registry.Data) error {
+		if len(d.Claims) != 2 {
+			t.Errorf("registry holds %v, want two claims", d.Claims)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"fn-gen/internal/cli"
+	"fn-gen/internal/generator"
+)
+
+// runStats reports the size of the combination space for the configured
+// lang/mode/pattern and how likely -count names are to collide:
+//
+//	fn-gen stats -mode startup -count 5000 -simulate -seed-template "TICKET-{n}"
+func runStats(ctx context.Context, cfg cli.Config) error {
+	if cfg.Backronym != "" {
+		return errors.New("-backronym only applies to generating names")
+	}
+	gen, err := newGenerator(cfg)
+	if err != nil {
+		return err
+	}
+
+	st, err := gen.Stats()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "lang:\t%s\n", cfg.Lang)
+	fmt.Fprintf(w, "mode:\t%s\n", cfg.Mode)
+	fmt.Fprintf(w, "pattern:\t%v\n", st.Pattern)
+	fmt.Fprintln(w, "categories:")
+	for _, c := range st.Categories {
+		if c.Distinct != c.Size {
+			fmt.Fprintf(w, "  %s\t%d\t(%d distinct)\n", c.Category, c.Size, c.Distinct)
+		} else {
+			fmt.Fprintf(w, "  %s\t%d\n", c.Category, c.Size)
+		}
+	}
+	fmt.Fprintf(w, "distinct names:\t%d\n", st.Distinct)
+	fmt.Fprintf(w, "names:\t%d\n", cfg.Count)
+	fmt.Fprintf(w, "collision probability:\t%.4f%%\t(birthday bound)\n",
+		100*generator.CollisionProbability(st.Distinct, cfg.Count))
+	fmt.Fprintf(w, "expected collisions:\t%.1f\n",
+		generator.ExpectedCollisions(st.Distinct, cfg.Count))
+
+	if cfg.Simulate {
+		sim, err := gen.Simulate(ctx, cfg.Count, cfg.SeedTemplate)
+		if err != nil {
+			return err
+		}
+		source := "automatic seeds"
+		if cfg.SeedTemplate != "" {
+			source = fmt.Sprintf("seeds %q", cfg.SeedTemplate)
+		}
+		fmt.Fprintf(w, "simulated collisions:\t%d\t(%d unique of %d, %s)\n",
+			sim.Collisions, sim.Unique, sim.Names, source)
+	}
+	return w.Flush()
+}
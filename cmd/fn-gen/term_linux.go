@@ -0,0 +1,9 @@
+package main
+
+import "syscall"
+
+// ioctl requests for the terminal attributes on Linux.
+const (
+	ioctlGetTermios = syscall.TCGETS
+	ioctlSetTermios = syscall.TCSETS
+)
@@ -0,0 +1,13 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd
+
+package main
+
+import (
+	"errors"
+	"os"
+)
+
+// rawTerminal is not supported on this platform.
+func rawTerminal(*os.File) (restore func(), err error) {
+	return nil, errors.New("pick is not supported on this platform")
+}
@@ -0,0 +1,38 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd
+
+package main
+
+import (
+	"errors"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// rawTerminal puts the terminal on f into raw mode: keys arrive one at a
+// time, unechoed, and Ctrl-C is read as a key rather than raised as a
+// signal. Output processing stays on, so "\n" still starts a new line.
+// The returned function restores the previous mode.
+func rawTerminal(f *os.File) (restore func(), err error) {
+	var old syscall.Termios
+	if err := termios(f, ioctlGetTermios, &old); err != nil {
+		return nil, errors.New("pick needs an interactive terminal")
+	}
+
+	raw := old
+	raw.Iflag &^= syscall.ICRNL | syscall.IXON | syscall.BRKINT | syscall.INPCK | syscall.ISTRIP
+	raw.Lflag &^= syscall.ECHO | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	raw.Cc[syscall.VMIN], raw.Cc[syscall.VTIME] = 1, 0
+	if err := termios(f, ioctlSetTermios, &raw); err != nil {
+		return nil, err
+	}
+	return func() { _ = termios(f, ioctlSetTermios, &old) }, nil
+}
+
+// termios gets or sets the terminal attributes of f with ioctl request req.
+func termios(f *os.File, req uintptr, t *syscall.Termios) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), req, uintptr(unsafe.Pointer(t))); errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"fn-gen/internal/cli"
+	"fn-gen/internal/generator"
+	"fn-gen/internal/words"
+)
+
+// runValidate lints word files and exits non-zero if any error was found:
+//
+//	fn-gen validate                                  # all bundled packs
+//	fn-gen validate internal/words/data/de/startup.json
+func runValidate(cfg cli.Config) error {
+	path := words.DataDir
+	if len(cfg.Args) > 0 {
+		path = cfg.Args[0]
+	}
+
+	// Every built-in mode requires the categories of its pattern
+	patterns := make(map[string][]string)
+	for _, m := range generator.Modes() {
+		patterns[string(m)] = generator.Pattern(m)
+	}
+
+	issues, err := words.Validate(path, patterns)
+	if err != nil {
+		return err
+	}
+
+	var errs, warnings int
+	for _, i := range issues {
+		fmt.Println(i)
+		if i.Severity == words.Error {
+			errs++
+		} else {
+			warnings++
+		}
+	}
+	fmt.Fprintf(os.Stderr, "%d error(s), %d warning(s)\n", errs, warnings)
+
+	if errs > 0 {
+		return fmt.Errorf("validation failed: %s", path)
+	}
+	return nil
+}
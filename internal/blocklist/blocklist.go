@@ -0,0 +1,223 @@
+package blocklist
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// TrademarksFile is the bundled list of trademarked terms, relative to the
+// project root. It is only consulted when asked for (-trademarks).
+const TrademarksFile = "internal/blocklist/data/trademarks.txt"
+
+// Kind is the type of a blocklist rule.
+type Kind int
+
+const (
+	Name   Kind = iota // The whole name, e.g. "Smart Data Hub"
+	Word               // A word or phrase anywhere in the name, e.g. "Kleenex"
+	Pair               // Two words or phrases that must not appear together
+	Regexp             // A regular expression matched against the name
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Word:
+		return "word"
+	case Pair:
+		return "pair"
+	case Regexp:
+		return "regexp"
+	default:
+		return "name"
+	}
+}
+
+// Rule is a single blocklist entry and where it was defined.
+type Rule struct {
+	Kind   Kind   // What the rule matches
+	Text   string // The rule as written, without its prefix
+	Source string // File the rule came from
+	Line   int    // 1-based line in Source
+
+	terms [][]string     // Normalised tokens: the name, the word, or both words of a pair
+	re    *regexp.Regexp // Compiled expression for Regexp rules
+}
+
+// String formats the rule for explain output, e.g.
+// `pair "Cloud + Butt" (team.txt:4)`.
+func (r Rule) String() string {
+	return fmt.Sprintf("%s %q (%s:%d)", r.Kind, r.Text, r.Source, r.Line)
+}
+
+// match reports whether the rule blocks a name given as normalised tokens.
+func (r Rule) match(name string, tokens []string) bool {
+	switch r.Kind {
+	case Name:
+		return slices.Equal(tokens, r.terms[0])
+	case Regexp:
+		return r.re.MatchString(name)
+	default: // Word and Pair: every term must occur somewhere in the name
+		for _, term := range r.terms {
+			if !containsRun(tokens, term) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// List is a set of rules. The zero value and a nil *List block nothing.
+// A List is not modified by Match and is safe for concurrent use.
+type List struct {
+	rules []Rule
+}
+
+// Parse reads rules from r, one per line. source names the input in rule
+// positions and error messages.
+//
+// Syntax:
+//
+//	# comment (blank lines are ignored too)
+//	Smart Data Hub            exact name
+//	name: Smart Data Hub      exact name, explicit
+//	word: Kleenex             word or phrase anywhere in the name
+//	pair: Cloud + Butt        both words anywhere in the same name
+//	re: (?i)^x.*x$            regular expression (RE2) on the name
+//
+// Names, words and pairs match case-insensitively and treat spaces and
+// hyphens alike, so "word: ai powered" blocks "AI-Powered".
+func Parse(source string, r io.Reader) (*List, error) {
+	l := &List{}
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rule, err := parseRule(line)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", source, n, err)
+		}
+		rule.Source, rule.Line = source, n
+		l.rules = append(l.rules, rule)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+	return l, nil
+}
+
+// parseRule parses a single non-empty, non-comment line.
+func parseRule(line string) (Rule, error) {
+	kind, text := Name, line
+	if prefix, rest, ok := strings.Cut(line, ":"); ok {
+		switch strings.TrimSpace(prefix) {
+		case "name":
+			kind, text = Name, rest
+		case "word":
+			kind, text = Word, rest
+		case "pair":
+			kind, text = Pair, rest
+		case "re":
+			kind, text = Regexp, rest
+		}
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return Rule{}, fmt.Errorf("empty %s rule", kind)
+	}
+
+	rule := Rule{Kind: kind, Text: text}
+	switch kind {
+	case Regexp:
+		re, err := regexp.Compile(text)
+		if err != nil {
+			return Rule{}, fmt.Errorf("invalid regexp: %w", err)
+		}
+		rule.re = re
+	case Pair:
+		first, second, ok := strings.Cut(text, "+")
+		if !ok {
+			return Rule{}, fmt.Errorf("pair %q must have the form \"first + second\"", text)
+		}
+		rule.terms = [][]string{Tokens(first), Tokens(second)}
+	default:
+		rule.terms = [][]string{Tokens(text)}
+	}
+
+	// A term without letters or digits would match every name
+	for _, term := range rule.terms {
+		if len(term) == 0 {
+			return Rule{}, fmt.Errorf("%s %q has an empty term", kind, text)
+		}
+	}
+	return rule, nil
+}
+
+// Load reads and merges the rules of every file in paths, in order.
+func Load(paths ...string) (*List, error) {
+	l := &List{}
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load blocklist: %w", err)
+		}
+		part, err := Parse(path, f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("cannot load blocklist: %w", err)
+		}
+		l.rules = append(l.rules, part.rules...)
+	}
+	return l, nil
+}
+
+// Len returns the number of rules.
+func (l *List) Len() int {
+	if l == nil {
+		return 0
+	}
+	return len(l.rules)
+}
+
+// Match returns the first rule that blocks name, in file order.
+func (l *List) Match(name string) (Rule, bool) {
+	if l.Len() == 0 {
+		return Rule{}, false
+	}
+
+	tokens := Tokens(name)
+	for _, r := range l.rules {
+		if r.match(name, tokens) {
+			return r, true
+		}
+	}
+	return Rule{}, false
+}
+
+// Tokens splits s into lower-cased words, treating any run of characters
+// other than letters, digits and marks as a separator:
+//
+//	Tokens("AI-Powered  Data Hub") == []string{"ai", "powered", "data", "hub"}
+func Tokens(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsMark(r)
+	})
+}
+
+// containsRun reports whether run occurs as a contiguous sequence in tokens.
+func containsRun(tokens, run []string) bool {
+	for i := 0; i+len(run) <= len(tokens); i++ {
+		if slices.Equal(tokens[i:i+len(run)], run) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+package blocklist
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func mustParse(t *testing.T, src string) *List {
+	t.Helper()
+	l, err := Parse("test.txt", strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	return l
+}
+
+func TestTokens(t *testing.T) {
+	got := Tokens("AI-Powered  Data Hub")
+	if want := []string{"ai", "powered", "data", "hub"}; !slices.Equal(got, want) {
+		t.Errorf("Tokens = %v, want %v", got, want)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	l := mustParse(t, `
+# comment
+Smart Data Hub
+word: AI Powered
+pair: Cloud + Butt
+re: ^Fast .* Pro$
+`)
+
+	tests := []struct {
+		name string
+		kind Kind
+		want bool
+	}{
+		{"smart  data-hub", Name, true},     // case, spacing and hyphens are ignored
+		{"Smart Data Hub Pro", Name, false}, // names match as a whole
+		{"Unified AI-Powered Engine", Word, true},
+		{"Powered AI Engine", Word, false}, // phrases keep their order
+		{"Butt Plug Cloud", Pair, true},    // pairs match anywhere
+		{"Cloud Engine", Pair, false},
+		{"Fast Cloud Engine Pro", Regexp, true},
+		{"fast Engine Pro", Regexp, false}, // regexps are case-sensitive unless (?i)
+		{"Hubcap", Name, false},            // words are not substrings
+	}
+	for _, tt := range tests {
+		rule, got := l.Match(tt.name)
+		if got != tt.want {
+			t.Errorf("Match(%q) = %v, want %v", tt.name, got, tt.want)
+			continue
+		}
+		if got && rule.Kind != tt.kind {
+			t.Errorf("Match(%q) kind = %s, want %s", tt.name, rule.Kind, tt.kind)
+		}
+	}
+}
+
+func TestMatch_FirstRuleWins(t *testing.T) {
+	l := mustParse(t, "word: Cloud\nname: Cloud Hub\n")
+
+	rule, ok := l.Match("Cloud Hub")
+	if !ok || rule.Line != 1 {
+		t.Errorf("got %v, want the rule on line 1", rule)
+	}
+	if want := `word "Cloud" (test.txt:1)`; rule.String() != want {
+		t.Errorf("String() = %q, want %q", rule.String(), want)
+	}
+}
+
+func TestMatch_NilAndEmpty(t *testing.T) {
+	var l *List
+	if _, ok := l.Match("Anything"); ok {
+		t.Error("nil list should block nothing")
+	}
+	if l.Len() != 0 {
+		t.Error("nil list should be empty")
+	}
+	if _, ok := mustParse(t, "# only a comment\n").Match("Anything"); ok {
+		t.Error("empty list should block nothing")
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{"word:", "test.txt:1: empty word rule"},
+		{"\npair: Cloud", "test.txt:2: pair"},
+		{"pair: Cloud + ---", "empty term"},
+		{"re: (", "invalid regexp"},
+		{"!!!", "empty term"},
+	}
+	for _, tt := range tests {
+		_, err := Parse("test.txt", strings.NewReader(tt.src))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Parse(%q) error = %v, want %q", tt.src, err, tt.want)
+		}
+	}
+}
+
+func TestLoad_MergesFiles(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.txt")
+	b := filepath.Join(dir, "b.txt")
+	os.WriteFile(a, []byte("word: Cloud\n"), 0o644)
+	os.WriteFile(b, []byte("word: Edge\n"), 0o644)
+
+	l, err := Load(a, b)
+	if err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+	if l.Len() != 2 {
+		t.Fatalf("Len = %d, want 2", l.Len())
+	}
+	if rule, ok := l.Match("Edge Hub"); !ok || rule.Source != b {
+		t.Errorf("got %v, want a rule from %s", rule, b)
+	}
+
+	if _, err := Load(filepath.Join(dir, "missing.txt")); err == nil {
+		t.Error("expected error for a missing file")
+	}
+}
+
+func TestLoad_BundledTrademarks(t *testing.T) {
+	l, err := Load(filepath.Join("..", "..", TrademarksFile))
+	if err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+	if l.Len() == 0 {
+		t.Fatal("bundled trademark list is empty")
+	}
+	if _, ok := l.Match("Quantum Neural Engine Suite"); !ok {
+		t.Error("expected the bundled list to block \"Neural Engine\"")
+	}
+}
@@ -0,0 +1,97 @@
+package blocklist
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Excludes is a set of names that are already in use, keyed by Key.
+// The zero value and a nil *Excludes exclude nothing. An Excludes is not
+// modified by Match and is safe for concurrent use.
+type Excludes struct {
+	source string         // File the names came from
+	lines  map[string]int // Key → 1-based line of its first occurrence
+}
+
+// ParseExcludes reads names from r, one per line. Blank lines and lines
+// starting with '#' are ignored. source names the input in match reasons
+// and error messages.
+func ParseExcludes(source string, r io.Reader) (*Excludes, error) {
+	e := &Excludes{source: source, lines: make(map[string]int)}
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key := Key(line)
+		if key == "" {
+			continue // Nothing a generated name could normalise to
+		}
+		if _, dup := e.lines[key]; !dup {
+			e.lines[key] = n
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+	return e, nil
+}
+
+// LoadExcludes reads the names in the file at path.
+func LoadExcludes(path string) (*Excludes, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load exclude file: %w", err)
+	}
+	defer f.Close()
+
+	e, err := ParseExcludes(path, f)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load exclude file: %w", err)
+	}
+	return e, nil
+}
+
+// Len returns the number of distinct names.
+func (e *Excludes) Len() int {
+	if e == nil {
+		return 0
+	}
+	return len(e.lines)
+}
+
+// Match reports whether name is already in use and, if so, where it was
+// listed, e.g. "used.txt:12".
+func (e *Excludes) Match(name string) (where string, ok bool) {
+	if e.Len() == 0 {
+		return "", false
+	}
+	line, ok := e.lines[Key(name)]
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("%s:%d", e.source, line), true
+}
+
+// Slug normalises a name for comparison: lower case, with every run of
+// characters other than letters, digits and marks turned into a single
+// hyphen, and no leading or trailing hyphen:
+//
+//	Slug("Smart  Data-Hub!") == "smart-data-hub"
+//	Slug("smart_data_hub")   == "smart-data-hub"
+func Slug(name string) string {
+	return strings.Join(Tokens(name), "-")
+}
+
+// Key normalises a name for matching: its slug without the hyphens, so
+// that every -case form of a name is the same name:
+//
+//	Key("Clean Layer") == Key("CleanLayer") == Key("clean_layer") == "cleanlayer"
+func Key(name string) string {
+	return strings.Join(Tokens(name), "")
+}
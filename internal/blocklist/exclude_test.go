@@ -0,0 +1,89 @@
+package blocklist
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSlug(t *testing.T) {
+	tests := map[string]string{
+		"Smart Data Hub":   "smart-data-hub",
+		"smart-data-hub":   "smart-data-hub",
+		"Smart  Data-Hub!": "smart-data-hub",
+		"smart_data_hub":   "smart-data-hub",
+		" KI-unterstützt ": "ki-unterstützt",
+		"---":              "",
+	}
+	for in, want := range tests {
+		if got := Slug(in); got != want {
+			t.Errorf("Slug(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestKey(t *testing.T) {
+	for _, in := range []string{"Clean Layer", "CleanLayer", "cleanLayer", "clean-layer", "clean_layer", "CLEAN LAYER"} {
+		if got := Key(in); got != "cleanlayer" {
+			t.Errorf("Key(%q) = %q, want cleanlayer", in, got)
+		}
+	}
+}
+
+func TestExcludes_Match(t *testing.T) {
+	e, err := ParseExcludes("used.txt", strings.NewReader(`# assigned names
+Smart Data Hub
+
+cloud-native-engine
+SMART DATA HUB
+`))
+	if err != nil {
+		t.Fatalf("ParseExcludes error: %v", err)
+	}
+	if e.Len() != 2 {
+		t.Errorf("Len = %d, want 2", e.Len())
+	}
+
+	tests := []struct {
+		name  string
+		where string
+		ok    bool
+	}{
+		{"smart data hub", "used.txt:2", true}, // first occurrence wins
+		{"Cloud-Native Engine", "used.txt:4", true},
+		{"SmartDataHub", "used.txt:2", true}, // any -case form
+		{"cloudNativeEngine", "used.txt:4", true},
+		{"Cloud Native Engine Pro", "", false},
+	}
+	for _, tt := range tests {
+		where, ok := e.Match(tt.name)
+		if ok != tt.ok || where != tt.where {
+			t.Errorf("Match(%q) = %q, %v; want %q, %v", tt.name, where, ok, tt.where, tt.ok)
+		}
+	}
+}
+
+func TestExcludes_Nil(t *testing.T) {
+	var e *Excludes
+	if _, ok := e.Match("Anything"); ok || e.Len() != 0 {
+		t.Error("nil Excludes should exclude nothing")
+	}
+}
+
+func TestLoadExcludes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "used.txt")
+	os.WriteFile(path, []byte("Smart Data Hub\n"), 0o644)
+
+	e, err := LoadExcludes(path)
+	if err != nil {
+		t.Fatalf("LoadExcludes error: %v", err)
+	}
+	if where, ok := e.Match("smart-data-hub"); !ok || where != path+":1" {
+		t.Errorf("Match = %q, %v", where, ok)
+	}
+
+	if _, err := LoadExcludes(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("expected error for a missing file")
+	}
+}
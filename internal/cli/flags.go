@@ -1,33 +1,191 @@
 package cli
 
-import "flag"
+import (
+	"errors"
+	"flag"
+	"os"
+	"strings"
+)
 
 type Config struct {
+	Command string // Subcommand (e.g. "enumerate"); empty means generate
+	Args    []string
+
 	Lang    string
 	Mode    string
+	Pack    string   // Word pack file overriding the bundled {lang}/{mode} pack
+	Pattern []string // Category pattern overriding the mode's default (empty = use mode)
+	Join    string   // How compounding languages write core and suffix: "spaced" or "compound"
+	Case    string   // Output case of names: "title", "lower", "upper", "kebab", "snake", "camel" or "pascal"
 	Seed    string
 	Count   int
 	Explain bool
+	Workers int
+
+	Alliterate   string // Every word starts alike: "letter", "sound" or "" (off)
+	StartsWith   string // Names start with this prefix (case- and accent-insensitive)
+	Sequence     int    // Names start with the N-th letter of Alphabet that has names (0 = off)
+	Alphabet     string // Letters -sequence advances through ("" = A to Z)
+	MaxSyllables int    // Maximum syllables per name (0 = no limit)
+	MinLength    int    // Minimum characters per name after -case (0 = no limit)
+	MaxLength    int    // Maximum characters per name after -case (0 = no limit)
+
+	Acronym       bool   // Print each name's acronym after it
+	Pronounceable bool   // Only names whose acronym reads as a word
+	AcronymDict   string // File of words; only names whose acronym is one of them
+	Backronym     string // Target word; each letter starts one word of the name
+
+	Fix []string // Pinned words, "category=Word" or "position=Word" (repeatable)
+
+	Blocklists []string // Blocklist files; names matching any rule are replaced
+	Trademarks bool     // Also apply the bundled trademark blocklist
+	Exclude    string   // File of names already in use; matching names are replaced
+	Registry   string   // Registry file of claimed names (claim/release/lookup; optional for generate)
+
+	Offset uint64 // enumerate: number of combinations to skip
+	Limit  uint64 // enumerate: maximum number of combinations to print (0 = all)
+
+	Simulate     bool   // stats: also count collisions empirically
+	SeedTemplate string // stats: seed template for simulation, "{n}" is replaced by the index
 }
 
+// ParseFlags reads the command line into a Config.
+//
+// An optional subcommand may precede the flags:
+//
+//	fn-gen [command] [flags] [args]
+//
+// The subcommand is only recognised as the first argument and must not
+// start with a dash; any positional arguments after the flags end up in
+// Config.Args. Validation of the command name is left to the caller.
 func ParseFlags() Config {
+	cfg, _ := Parse(flag.CommandLine, os.Args[1:]) // CommandLine exits on errors
+	return cfg
+}
+
+// Parse reads args, the command line without the program name, into a
+// Config like ParseFlags does, defining the flags on fs.
+func Parse(fs *flag.FlagSet, args []string) (Config, error) {
 	var cfg Config
 
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		cfg.Command = args[0]
+		args = args[1:]
+	}
+
 	// Language flag: determines which language-specific word files to load
-	flag.StringVar(&cfg.Lang, "lang", "en", "language (en, de)")
+	fs.StringVar(&cfg.Lang, "lang", "en", "BCP 47 language tag (en, de, fr, es, nl, it); regional tags such as de-CH fall back to de, then en")
 
 	// Mode flag: controls the complexity and style of generated names
-	flag.StringVar(&cfg.Mode, "mode", "startup", "mode (startup, enterprise, bullshit, minimal)")
+	fs.StringVar(&cfg.Mode, "mode", "startup", "mode (startup, enterprise, bullshit, minimal, codename, scientist, cosmic, landmark)")
+
+	// Pack flag: load words from a custom pack file (which may extend a bundled pack)
+	fs.StringVar(&cfg.Pack, "pack", "", "word pack file to load instead of the bundled {lang}/{mode} pack")
+
+	// Pattern flag: comma-separated categories replacing the mode's pattern
+	fs.Func("pattern", "comma-separated category pattern, e.g. adjectives,core (default: from mode)", func(v string) error {
+		cfg.Pattern = nil
+		for key := range strings.SplitSeq(v, ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				cfg.Pattern = append(cfg.Pattern, key)
+			}
+		}
+		return nil
+	})
+
+	// Join flag: compound nouns (German "Datenpipeline") or separate words
+	fs.StringVar(&cfg.Join, "join", "spaced", "how to join core and suffix in languages with compound nouns (spaced, compound)")
+
+	// Case flag: output form of names, e.g. kebab case for resource identifiers
+	fs.StringVar(&cfg.Case, "case", "title", "output case (title, lower, upper, kebab, snake, camel, pascal)")
 
 	// Seed flag: when provided, ensures deterministic name generation
-	flag.StringVar(&cfg.Seed, "seed", "", "deterministic seed")
+	fs.StringVar(&cfg.Seed, "seed", "", "deterministic seed")
 
 	// Count flag: allows batch generation of multiple names
-	flag.IntVar(&cfg.Count, "count", 1, "number of names")
+	fs.IntVar(&cfg.Count, "count", 1, "number of names")
 
 	// Explain flag: enables verbose output showing how each name was generated
-	flag.BoolVar(&cfg.Explain, "explain", false, "explain how the name was generated")
+	fs.BoolVar(&cfg.Explain, "explain", false, "explain how the name was generated")
 
-	flag.Parse()
-	return cfg
+	// Constraint flags: names that do not fit are replaced by the next candidate
+	fs.Var(alliterateFlag{&cfg.Alliterate}, "alliterate", "all words start with the same letter; -alliterate=sound matches opening sounds (C/K, Ph/F, vowels)")
+	fs.StringVar(&cfg.StartsWith, "starts-with", "", "names start with this prefix")
+	fs.IntVar(&cfg.Sequence, "sequence", 0, "release number N: names start with the N-th letter of -alphabet, skipping letters without names (0 = off)")
+	fs.StringVar(&cfg.Alphabet, "alphabet", "", "letters -sequence advances through (default A to Z)")
+	fs.IntVar(&cfg.MaxSyllables, "max-syllables", 0, "maximum syllables per name (0 = no limit)")
+	fs.IntVar(&cfg.MinLength, "min-length", 0, "minimum characters per name, counted after -case (0 = no limit)")
+	fs.IntVar(&cfg.MaxLength, "max-length", 0, "maximum characters per name, counted after -case (0 = no limit)")
+
+	// Acronym flags: print acronyms, filter names by them, or spell a word
+	fs.BoolVar(&cfg.Acronym, "acronym", false, "print each name's acronym after it")
+	fs.BoolVar(&cfg.Pronounceable, "pronounceable", false, "only names whose acronym is pronounceable")
+	fs.StringVar(&cfg.AcronymDict, "acronym-dict", "", "file of words, one per line; only names whose acronym is one of them")
+	fs.StringVar(&cfg.Backronym, "backronym", "", "word to spell: each letter starts one word, with categories cycling through the pattern")
+
+	// Fix flag: pin words, deriving only the other positions (repeatable)
+	fs.Func("fix", "pin a word, as category=Word or position=Word, e.g. -fix suffix=Hub (repeatable)", func(v string) error {
+		cfg.Fix = append(cfg.Fix, v)
+		return nil
+	})
+
+	// Blocklist flags: reject names and derive replacements (repeatable)
+	fs.Func("blocklist", "blocklist file of names, words, pairs and regexps to reject (repeatable)", func(v string) error {
+		cfg.Blocklists = append(cfg.Blocklists, v)
+		return nil
+	})
+	fs.BoolVar(&cfg.Trademarks, "trademarks", false, "also reject names containing bundled trademark terms")
+
+	// Exclude flag: names already assigned elsewhere, in any case or spacing
+	fs.StringVar(&cfg.Exclude, "exclude-file", "", "file of already-used names, one per line, to skip (matched ignoring case, spacing and punctuation)")
+
+	// Registry flag: claimed names, shared between runs and machines
+	fs.StringVar(&cfg.Registry, "registry", "", "registry file of claimed names (default for claim, release and lookup: .fn-gen-registry.json)")
+
+	// Workers flag: parallelism for bulk output (0 = one worker per CPU)
+	fs.IntVar(&cfg.Workers, "workers", 0, "number of generator goroutines for bulk output (0 = GOMAXPROCS)")
+
+	// Paging flags for the enumerate command
+	fs.Uint64Var(&cfg.Offset, "offset", 0, "enumerate: number of combinations to skip")
+	fs.Uint64Var(&cfg.Limit, "limit", 0, "enumerate: maximum number of combinations (0 = all)")
+
+	// Collision flags for the stats command (-count is the number of names)
+	fs.BoolVar(&cfg.Simulate, "simulate", false, "stats: simulate -count names and count actual collisions")
+	fs.StringVar(&cfg.SeedTemplate, "seed-template", "", "stats: seed template for -simulate, {n} is replaced by the index (default: automatic seeds)")
+
+	if err := fs.Parse(args); err != nil {
+		return Config{}, err
+	}
+	cfg.Args = fs.Args()
+	return cfg, nil
+}
+
+// alliterateFlag is the -alliterate flag. It is a boolean flag, so plain
+// -alliterate means "letter", but it also accepts -alliterate=sound.
+type alliterateFlag struct {
+	value *string
+}
+
+func (f alliterateFlag) String() string {
+	if f.value == nil {
+		return ""
+	}
+	return *f.value
+}
+
+func (f alliterateFlag) Set(v string) error {
+	switch v {
+	case "true", "letter":
+		*f.value = "letter"
+	case "sound":
+		*f.value = "sound"
+	case "false":
+		*f.value = ""
+	default:
+		return errors.New("must be letter or sound")
+	}
+	return nil
 }
+
+// IsBoolFlag lets the flag package accept -alliterate without a value.
+func (alliterateFlag) IsBoolFlag() bool { return true }
@@ -0,0 +1,123 @@
+package generator
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"fn-gen/internal/grammar"
+)
+
+// backronym holds the -backronym target prepared for the generator's
+// pattern: one word per letter, so that the name's acronym spells the
+// target.
+//
+// The letters take their categories from the pattern in turn, cycling
+// when the target is longer. If the category in turn has no word with
+// the letter, the following categories of the cycle are tried:
+//
+//	-backronym HERMES, pattern adjectives,core,suffix:
+//	H adjectives, E core, R suffix → adjectives, M adjectives, E core, S suffix
+//
+// The words keep the letter order whatever the language, so they are
+// joined with spaces rather than composed by the language's rules.
+type backronym struct {
+	key     []string       // Stream key: the pattern, then the letters
+	letters []backronymPos // One position per letter of the target
+	err     error          // Why the target cannot be spelt
+}
+
+// backronymPos is the category and the words a letter is spelt with.
+type backronymPos struct {
+	letter string   // Upper case without accents
+	key    string   // Pattern key the words come from
+	cycled string   // Pattern key in turn, if it had no word and key stood in
+	words  []string // Words of key that start with letter
+}
+
+// newBackronym prepares the backronym of target for the generator's
+// pattern. It must run after the options are applied, like newConstraints.
+func (g *Generator) newBackronym(target string) *backronym {
+	b := &backronym{}
+	if target == "" || strings.ContainsFunc(target, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		b.err = fmt.Errorf("backronym %q must consist of letters and digits only", target)
+		return b
+	}
+
+	letters := strings.Split(strings.ToUpper(grammar.Fold(target)), "")
+	b.key = append(slices.Clone(g.pattern), letters...)
+
+	var missing []string // Letters no category has a word for, with their position
+	for j, letter := range letters {
+		pos := backronymPos{letter: letter}
+		for k := range g.pattern {
+			key := g.pattern[(j+k)%len(g.pattern)]
+			if pos.words = filterWords(g.list(key), func(w string) bool { return grammar.Acronym(w) == letter }); len(pos.words) > 0 {
+				pos.key = key
+				if k > 0 {
+					pos.cycled = g.pattern[j%len(g.pattern)]
+				}
+				break
+			}
+		}
+		if pos.key == "" {
+			missing = append(missing, fmt.Sprintf("%s (letter %d)", letter, j+1))
+		}
+		b.letters = append(b.letters, pos)
+	}
+
+	// Report every letter at once, so the target can be fixed in one go
+	if len(missing) > 0 {
+		b.err = fmt.Errorf("%w: cannot spell %s: no word in %s starts with %s",
+			ErrUnsatisfiable, target, strings.Join(uniqueKeys(g.pattern), ", "), strings.Join(missing, ", "))
+	}
+	return b
+}
+
+// spell draws one value per letter from stream and builds the backronym
+// they select, like candidate does for a pattern.
+func (g *Generator) spell(stream *Stream) (ExplainedResult, []grammar.Word) {
+	b := g.back
+	parts := make([]ExplainedPart, 0, len(b.letters))
+	selected := make([]grammar.Word, 0, len(b.letters))
+	for _, pos := range b.letters {
+		hash := stream.Next()
+		idx := hash % uint64(len(pos.words))
+		word := pos.words[idx]
+
+		ws, category := g.source(pos.key)
+		selected = append(selected, grammar.Word{Category: category, Text: word, Features: ws.Features(word)})
+		origin := ws.Origin(category, word)
+		parts = append(parts, ExplainedPart{
+			Category: pos.key,
+			Word:     word,
+			Hash:     hash,
+			Index:    idx,
+			ListSize: len(pos.words),
+			Origin:   origin,
+			Letter:   pos.letter,
+			Cycled:   pos.cycled,
+		})
+		if ws.Lang != "" && origin.Lang != ws.Lang {
+			parts[len(parts)-1].FallbackFor = ws.Lang
+		}
+	}
+
+	return ExplainedResult{
+		Name:    grammar.Spaced{}.Compose(selected),
+		Pattern: g.pattern,
+		Parts:   parts,
+	}, selected
+}
+
+// uniqueKeys returns the keys of pattern without repetitions, in order.
+func uniqueKeys(pattern []string) []string {
+	var keys []string
+	for _, key := range pattern {
+		if !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,98 @@
+package generator
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"fn-gen/internal/grammar"
+)
+
+func TestBackronym_SpellsTarget(t *testing.T) {
+	cfg := testConfig("startup", "")
+	cfg.Backronym = "sepsp"
+	g := New(testWordSet(), cfg)
+
+	seen := make(map[string]bool)
+	for i := range 20 {
+		result := mustExplain(t, g, i)
+		if result.Acronym != "SEPSP" || grammar.Acronym(result.Name) != "SEPSP" {
+			t.Errorf("%q (acronym %q) does not spell SEPSP", result.Name, result.Acronym)
+		}
+		seen[result.Name] = true
+	}
+	// The first P is Pro or Plus
+	if len(seen) < 2 {
+		t.Errorf("got only %v, want the letters' words to vary", seen)
+	}
+}
+
+func TestBackronym_CyclesThroughPattern(t *testing.T) {
+	cfg := testConfig("startup", "x")
+	cfg.Backronym = "SEPSP"
+	result := mustExplain(t, New(testWordSet(), cfg), 0)
+
+	// The fifth letter falls on core, the second round of the cycle
+	var letters, categories []string
+	for _, p := range result.Parts {
+		letters = append(letters, p.Letter)
+		categories = append(categories, p.Category)
+	}
+	if want := []string{"S", "E", "P", "S", "P"}; !slices.Equal(letters, want) {
+		t.Errorf("letters = %v, want %v", letters, want)
+	}
+	if want := []string{"adjectives", "core", "suffix", "adjectives", "core"}; !slices.Equal(categories, want) {
+		t.Errorf("categories = %v, want %v", categories, want)
+	}
+}
+
+func TestBackronym_FallsBackToNextCategory(t *testing.T) {
+	cfg := testConfig("startup", "x")
+	cfg.Backronym = "BHG"
+	result := mustExplain(t, New(testWordSet(), cfg), 0)
+
+	// H falls on core, which has no H: the suffix Hub stands in. G falls
+	// on suffix and wraps around past adjectives to core
+	if result.Name != "Bold Hub Gateway" {
+		t.Errorf("got %q, want %q", result.Name, "Bold Hub Gateway")
+	}
+	h, g := result.Parts[1], result.Parts[2]
+	if h.Category != "suffix" || h.Cycled != "core" {
+		t.Errorf("H: category %q cycled %q, want suffix standing in for core", h.Category, h.Cycled)
+	}
+	if g.Category != "core" || g.Cycled != "suffix" {
+		t.Errorf("G: category %q cycled %q, want core standing in for suffix", g.Category, g.Cycled)
+	}
+	if result.Parts[0].Cycled != "" {
+		t.Errorf("B: cycled %q, want its own category", result.Parts[0].Cycled)
+	}
+}
+
+func TestBackronym_Deterministic(t *testing.T) {
+	cfg := testConfig("startup", "ticket-1")
+	cfg.Backronym = "SEP"
+	a, b := mustGenerate(t, New(testWordSet(), cfg), 0), mustGenerate(t, New(testWordSet(), cfg), 5)
+	if a != b {
+		t.Errorf("same seed gave %q and %q", a, b)
+	}
+}
+
+func TestBackronym_Errors(t *testing.T) {
+	tests := []struct {
+		target string
+		want   string
+		unsat  bool
+	}{
+		{"BQZ", "cannot spell BQZ: no word in adjectives, core, suffix starts with Q (letter 2), Z (letter 3)", true},
+		{"B-2", `backronym "B-2" must consist of letters and digits only`, false},
+	}
+	for _, tt := range tests {
+		cfg := testConfig("startup", "x")
+		cfg.Backronym = tt.target
+		_, err := New(testWordSet(), cfg).Generate(0)
+		if err == nil || !strings.Contains(err.Error(), tt.want) || errors.Is(err, ErrUnsatisfiable) != tt.unsat {
+			t.Errorf("%s: error = %v, want %q", tt.target, err, tt.want)
+		}
+	}
+}
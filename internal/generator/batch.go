@@ -0,0 +1,52 @@
+package generator
+
+import (
+	"context"
+	"iter"
+)
+
+// GenerateN returns an iterator over n names starting at index start.
+// Each step yields the name for the next index and a nil error.
+//
+// The context is checked before every name. Once it is cancelled the
+// iterator yields a single ("", ctx.Err()) pair and stops, so callers can
+// tell a complete run from an interrupted one:
+//
+//	for name, err := range gen.GenerateN(ctx, 0, 1000) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(name)
+//	}
+func (g *Generator) GenerateN(ctx context.Context, start, n int) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		for result, err := range g.ExplainN(ctx, start, n) {
+			if !yield(result.Name, err) {
+				return
+			}
+		}
+	}
+}
+
+// ExplainN is the explained counterpart of GenerateN: it yields the full
+// ExplainedResult for each index in [start, start+n). Cancellation is
+// reported the same way, as a final zero result paired with ctx.Err(), and
+// so is a name that cannot be generated (ErrNoCandidate).
+func (g *Generator) ExplainN(ctx context.Context, start, n int) iter.Seq2[ExplainedResult, error] {
+	return func(yield func(ExplainedResult, error) bool) {
+		for i := start; i < start+n; i++ {
+			if err := ctx.Err(); err != nil {
+				yield(ExplainedResult{}, err)
+				return
+			}
+			result, err := g.GenerateExplained(i)
+			if err != nil {
+				yield(ExplainedResult{}, err)
+				return
+			}
+			if !yield(result, nil) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestGenerateN_MatchesGenerate(t *testing.T) {
+	g := New(largeWordSet(), testConfig("startup", ""))
+
+	i := 5
+	for name, err := range g.GenerateN(context.Background(), 5, 10) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := mustGenerate(t, g, i); name != want {
+			t.Errorf("index %d: got %q, want %q", i, name, want)
+		}
+		i++
+	}
+	if i != 15 {
+		t.Errorf("iterated to index %d, want 15", i)
+	}
+}
+
+func TestGenerateN_StopsOnCancel(t *testing.T) {
+	g := New(largeWordSet(), testConfig("startup", ""))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var names int
+	var lastErr error
+	for _, err := range g.GenerateN(ctx, 0, 100) {
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		names++
+		if names == 3 {
+			cancel()
+		}
+	}
+
+	if names != 3 {
+		t.Errorf("got %d names after cancel, want 3", names)
+	}
+	if !errors.Is(lastErr, context.Canceled) {
+		t.Errorf("err = %v, want %v", lastErr, context.Canceled)
+	}
+}
+
+func TestExplainN_EarlyBreak(t *testing.T) {
+	g := New(testWordSet(), testConfig("minimal", "seed"))
+
+	var seen int
+	for range g.ExplainN(context.Background(), 0, 10) {
+		seen++
+		if seen == 2 {
+			break
+		}
+	}
+
+	if seen != 2 {
+		t.Errorf("saw %d results, want 2", seen)
+	}
+}
+
+func TestGenerator_ConcurrentUse(t *testing.T) {
+	g := New(largeWordSet(), testConfig("enterprise", ""))
+
+	want := make([]string, 200)
+	for i := range want {
+		want[i] = mustGenerate(t, g, i)
+	}
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			for i := range want {
+				if got, err := g.Generate(i); err != nil || got != want[i] {
+					t.Errorf("index %d: got %q, want %q", i, got, want[i])
+				}
+			}
+		})
+	}
+	wg.Wait()
+}
@@ -0,0 +1,216 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"fn-gen/internal/blocklist"
+)
+
+func testBlocklist(t *testing.T, rules string) *blocklist.List {
+	t.Helper()
+	l, err := blocklist.Parse("test.txt", strings.NewReader(rules))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	return l
+}
+
+func TestBlocklist_ReplacesBlockedName(t *testing.T) {
+	cfg := testConfig("startup", "blocked-seed")
+	plain := mustExplain(t, New(testWordSet(), cfg), 0)
+
+	list := testBlocklist(t, "name: "+plain.Name+"\n")
+	g := New(testWordSet(), cfg, WithBlocklist(list))
+	result := mustExplain(t, g, 0)
+
+	if result.Name == plain.Name {
+		t.Fatalf("blocked name %q was issued", plain.Name)
+	}
+	if len(result.Rejected) != 1 || result.Rejected[0].Name != plain.Name {
+		t.Fatalf("Rejected = %v, want the blocked name", result.Rejected)
+	}
+	if !strings.Contains(result.Rejected[0].Reason, "test.txt:1") {
+		t.Errorf("reason %q does not name the rule", result.Rejected[0].Reason)
+	}
+
+	// The replacement is deterministic
+	if again := mustGenerate(t, New(testWordSet(), cfg, WithBlocklist(list)), 0); again != result.Name {
+		t.Errorf("replacement not deterministic: %q vs %q", again, result.Name)
+	}
+}
+
+func TestBlocklist_UnblockedNamesUnchanged(t *testing.T) {
+	cfg := testConfig("startup", "")
+	g := New(largeWordSet(), cfg, WithBlocklist(testBlocklist(t, "word: Nothing Matches\n")))
+	plain := New(largeWordSet(), cfg)
+
+	for i := range 100 {
+		result := mustExplain(t, g, i)
+		if want := mustGenerate(t, plain, i); result.Name != want {
+			t.Errorf("index %d: got %q, want %q", i, result.Name, want)
+		}
+		if len(result.Rejected) != 0 {
+			t.Errorf("index %d: unexpected rejections %v", i, result.Rejected)
+		}
+	}
+}
+
+func TestBlocklist_WordNeverIssued(t *testing.T) {
+	g := New(testWordSet(), testConfig("startup", ""), WithBlocklist(testBlocklist(t, "word: Smart\npair: Fast + Hub\n")))
+
+	for i := range 500 {
+		name := mustGenerate(t, g, i)
+		if strings.Contains(name, "Smart") || (strings.Contains(name, "Fast") && strings.Contains(name, "Hub")) {
+			t.Fatalf("index %d: blocked name %q issued", i, name)
+		}
+	}
+}
+
+func TestBlocklist_Exhausted(t *testing.T) {
+	g := New(testWordSet(), testConfig("startup", "x"), WithBlocklist(testBlocklist(t, "re: .\n")))
+
+	_, err := g.Generate(0)
+	if !errors.Is(err, ErrNoCandidate) {
+		t.Fatalf("got %v, want ErrNoCandidate", err)
+	}
+
+	// Bulk output stops with the same error
+	var out bytes.Buffer
+	if err := g.WriteBulk(context.Background(), &out, 0, 10, 2); !errors.Is(err, ErrNoCandidate) {
+		t.Errorf("WriteBulk error = %v, want ErrNoCandidate", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("WriteBulk wrote %q", out.String())
+	}
+}
+
+func TestBlocklist_EnumerateSkips(t *testing.T) {
+	g := New(testWordSet(), testConfig("minimal", ""), WithBlocklist(testBlocklist(t, "word: Fast\n")))
+
+	names := collect(t, g.Enumerate(context.Background(), 0, 0))
+	if len(names) != 6 {
+		t.Fatalf("got %d names, want 6 (9 minus the 3 with Fast): %v", len(names), names)
+	}
+	for _, name := range names {
+		if strings.Contains(name, "Fast") {
+			t.Errorf("blocked name %q enumerated", name)
+		}
+	}
+}
+
+func testExcludes(t *testing.T, names ...string) *blocklist.Excludes {
+	t.Helper()
+	e, err := blocklist.ParseExcludes("used.txt", strings.NewReader(strings.Join(names, "\n")))
+	if err != nil {
+		t.Fatalf("ParseExcludes error: %v", err)
+	}
+	return e
+}
+
+func TestExclude_SkipsUsedNames(t *testing.T) {
+	cfg := testConfig("startup", "")
+	plain := New(largeWordSet(), cfg)
+
+	// Mark the names of every even index as used, written as slugs
+	var used []string
+	for i := 0; i < 20; i += 2 {
+		used = append(used, blocklist.Slug(mustGenerate(t, plain, i)))
+	}
+	g := New(largeWordSet(), cfg, WithExclude(testExcludes(t, used...)))
+
+	for i := range 20 {
+		result := mustExplain(t, g, i)
+		if i%2 == 1 {
+			if want := mustGenerate(t, plain, i); result.Name != want {
+				t.Errorf("index %d: got %q, want unchanged %q", i, result.Name, want)
+			}
+			continue
+		}
+		if len(result.Rejected) != 1 || result.Rejected[0].Cause != Excluded {
+			t.Errorf("index %d: Rejected = %v, want one exclusion", i, result.Rejected)
+		}
+		if !strings.Contains(result.Rejected[0].Reason, "used.txt:") {
+			t.Errorf("index %d: reason %q does not name the file", i, result.Rejected[0].Reason)
+		}
+	}
+
+	if got := g.Rejections(Excluded); got != 10 {
+		t.Errorf("Rejections(Excluded) = %d, want 10", got)
+	}
+	if got := g.Rejections(Blocked); got != 0 {
+		t.Errorf("Rejections(Blocked) = %d, want 0", got)
+	}
+}
+
+func TestExclude_CountsAcrossBulk(t *testing.T) {
+	cfg := testConfig("startup", "")
+	first := mustGenerate(t, New(largeWordSet(), cfg), 0)
+	g := New(largeWordSet(), cfg, WithExclude(testExcludes(t, strings.ToUpper(first))))
+
+	var out bytes.Buffer
+	if err := g.WriteBulk(context.Background(), &out, 0, 5, 2); err != nil {
+		t.Fatalf("WriteBulk error: %v", err)
+	}
+	if strings.Contains(out.String(), first+"\n") {
+		t.Errorf("excluded name %q written", first)
+	}
+	if g.Rejections(Excluded) == 0 {
+		t.Error("expected the exclusion to be counted")
+	}
+}
+
+// mapRegistry is a Registry backed by a seed → name map.
+type mapRegistry map[string]string
+
+func (r mapRegistry) ClaimedName(seed string) (string, bool) {
+	name, ok := r[seed]
+	return name, ok
+}
+
+func (r mapRegistry) ClaimingSeed(name string) (string, bool) {
+	for seed, n := range r {
+		if blocklist.Slug(n) == blocklist.Slug(name) {
+			return seed, true
+		}
+	}
+	return "", false
+}
+
+func TestRegistry_ClaimedByOtherSeedIsSkipped(t *testing.T) {
+	taken := mustGenerate(t, New(testWordSet(), testConfig("startup", "JIRA-2")), 0)
+
+	g := New(testWordSet(), testConfig("startup", "JIRA-2"), WithRegistry(mapRegistry{"JIRA-1": taken}))
+	result := mustExplain(t, g, 0)
+	if result.Name == taken {
+		t.Fatalf("name %q claimed by JIRA-1 was issued for JIRA-2", taken)
+	}
+	if len(result.Rejected) != 1 || result.Rejected[0].Cause != Claimed || !strings.Contains(result.Rejected[0].Reason, `"JIRA-1"`) {
+		t.Errorf("Rejected = %v, want a claim by JIRA-1", result.Rejected)
+	}
+}
+
+func TestRegistry_ClaimedSeedKeepsItsName(t *testing.T) {
+	cfg := testConfig("startup", "JIRA-1")
+	claimed := mustExplain(t, New(testWordSet(), cfg), 0)
+
+	// Even if the name would now be blocked, the claim stands
+	list := testBlocklist(t, "name: "+claimed.Name+"\n")
+	g := New(testWordSet(), cfg, WithBlocklist(list), WithRegistry(mapRegistry{"JIRA-1": claimed.Name}))
+	result := mustExplain(t, g, 0)
+	if result.Name != claimed.Name || !result.Claimed {
+		t.Fatalf("got %q (claimed %v), want the claim %q", result.Name, result.Claimed, claimed.Name)
+	}
+	if len(result.Parts) != len(claimed.Parts) {
+		t.Errorf("replayed parts = %v, want %v", result.Parts, claimed.Parts)
+	}
+
+	// A claim the word set cannot produce is still returned, without parts
+	g = New(testWordSet(), cfg, WithRegistry(mapRegistry{"JIRA-1": "Legacy Name"}))
+	if result := mustExplain(t, g, 0); result.Name != "Legacy Name" || len(result.Parts) != 0 {
+		t.Errorf("got %+v, want the legacy claim without parts", result)
+	}
+}
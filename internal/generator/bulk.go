@@ -0,0 +1,137 @@
+package generator
+
+import (
+	"context"
+	"io"
+	"runtime"
+	"sync"
+)
+
+// bulkChunkSize is the number of names a worker renders before handing
+// its buffer to the writer. Large enough to amortise channel traffic,
+// small enough that the in-flight window stays a few megabytes.
+const bulkChunkSize = 4096
+
+// bulkChunk is one unit of work: a contiguous range of indices and the
+// channel its rendered output is delivered on.
+type bulkChunk struct {
+	start int             // First index in the chunk
+	count int             // Number of names in the chunk
+	out   chan bulkResult // Receives the rendered lines (buffered, capacity 1)
+}
+
+// bulkResult is a rendered chunk. If err is set, buf holds the lines
+// before the name that failed.
+type bulkResult struct {
+	buf *[]byte
+	err error
+}
+
+// WriteBulk writes count names, starting at index start, to w with one name
+// per line, formatted by Line. Names are produced by parallel worker
+// goroutines but appear in index order, exactly as a sequential loop over
+// GenerateExplained would print them.
+//
+// The work is split into fixed-size chunks. At most a small multiple of
+// workers chunks are in flight at any time, so memory stays bounded no
+// matter how large count is. A workers value <= 0 uses GOMAXPROCS.
+//
+// w should be buffered; WriteBulk issues one Write per chunk.
+// The first write or generation error stops generation and is returned;
+// the names before a failed one are still written. Cancelling ctx
+// stops the workers between names; everything written so far is a complete,
+// ordered prefix of the output and ctx.Err() is returned.
+func (g *Generator) WriteBulk(ctx context.Context, w io.Writer, start, count, workers int) error {
+	if count <= 0 {
+		return nil
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	// Buffers are recycled once the writer has flushed them
+	pool := sync.Pool{New: func() any {
+		buf := make([]byte, 0, bulkChunkSize*32)
+		return &buf
+	}}
+
+	jobs := make(chan bulkChunk)
+	// order carries chunks in index order; its capacity is the in-flight window
+	order := make(chan bulkChunk, workers*2)
+	stop := make(chan struct{})
+
+	// Producer: cut the index range into chunks and hand them out in order
+	go func() {
+		defer close(jobs)
+		defer close(order)
+		for offset := 0; offset < count; offset += bulkChunkSize {
+			c := bulkChunk{
+				start: start + offset,
+				count: min(bulkChunkSize, count-offset),
+				out:   make(chan bulkResult, 1),
+			}
+			// Hand the chunk to a worker before queueing it for the writer,
+			// so every chunk the writer waits on is guaranteed to be rendered
+			select {
+			case jobs <- c:
+			case <-stop:
+				return
+			}
+			select {
+			case order <- c:
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	// Workers: render each chunk into a pooled buffer
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Go(func() {
+			for c := range jobs {
+				buf := pool.Get().(*[]byte)
+				lines := (*buf)[:0]
+				var genErr error
+				for i := c.start; i < c.start+c.count; i++ {
+					// Bail out of the chunk early; the writer discards it
+					if ctx.Err() != nil {
+						break
+					}
+					result, err := g.GenerateExplained(i)
+					if err != nil {
+						genErr = err
+						break
+					}
+					lines = append(lines, g.Line(result)...)
+					lines = append(lines, '\n')
+				}
+				*buf = lines
+				c.out <- bulkResult{buf, genErr}
+			}
+		})
+	}
+
+	// Writer: drain chunks strictly in order
+	var err error
+	for c := range order {
+		res := <-c.out
+		if err == nil {
+			// A chunk finished after cancellation may be truncated, so the
+			// context is checked before each write rather than after
+			if err = ctx.Err(); err == nil {
+				_, err = w.Write(*res.buf)
+			}
+			if err == nil {
+				err = res.err
+			}
+			if err != nil {
+				close(stop)
+			}
+		}
+		pool.Put(res.buf)
+	}
+
+	wg.Wait()
+	return err
+}
@@ -0,0 +1,130 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWriteBulk_MatchesSequentialOrder(t *testing.T) {
+	g := New(largeWordSet(), testConfig("startup", ""))
+	count := bulkChunkSize*3 + 17 // Several chunks plus a partial one
+
+	var want strings.Builder
+	for i := range count {
+		want.WriteString(mustGenerate(t, g, i))
+		want.WriteByte('\n')
+	}
+
+	for _, workers := range []int{1, 3, 0} {
+		var got bytes.Buffer
+		if err := g.WriteBulk(context.Background(), &got, 0, count, workers); err != nil {
+			t.Fatalf("workers=%d: WriteBulk error: %v", workers, err)
+		}
+		if got.String() != want.String() {
+			t.Errorf("workers=%d: output differs from sequential generation", workers)
+		}
+	}
+}
+
+func TestWriteBulk_StartOffset(t *testing.T) {
+	g := New(largeWordSet(), testConfig("startup", ""))
+
+	var got bytes.Buffer
+	if err := g.WriteBulk(context.Background(), &got, 10, 2, 2); err != nil {
+		t.Fatalf("WriteBulk error: %v", err)
+	}
+
+	want := mustGenerate(t, g, 10) + "\n" + mustGenerate(t, g, 11) + "\n"
+	if got.String() != want {
+		t.Errorf("got %q, want %q", got.String(), want)
+	}
+}
+
+func TestWriteBulk_Acronym(t *testing.T) {
+	cfg := testConfig("startup", "")
+	cfg.Acronym, cfg.Case = true, "kebab"
+	g := New(testWordSet(), cfg)
+
+	var got bytes.Buffer
+	if err := g.WriteBulk(context.Background(), &got, 0, 1, 1); err != nil {
+		t.Fatalf("WriteBulk error: %v", err)
+	}
+
+	// The acronym comes from the name as composed, not the kebab-case one
+	result := mustExplain(t, g, 0)
+	want := result.Name + " (" + result.Acronym + ")\n"
+	if got.String() != want || len(result.Acronym) != 3 {
+		t.Errorf("got %q, want %q with a three-letter acronym", got.String(), want)
+	}
+}
+
+func TestGenerate_AcronymOnlyWhenAsked(t *testing.T) {
+	// Plain names skip the acronym, which would double the allocations
+	g := New(testWordSet(), testConfig("startup", ""))
+	if result := mustExplain(t, g, 0); result.Acronym != "" {
+		t.Errorf("Acronym = %q without an acronym flag", result.Acronym)
+	}
+	if allocs := testing.AllocsPerRun(100, func() { _, _ = g.Generate(0) }); allocs > 7 {
+		t.Errorf("Generate allocates %v times per name, want at most 7", allocs)
+	}
+
+	cfg := testConfig("startup", "")
+	cfg.Pronounceable = true
+	if result := mustExplain(t, New(testWordSet(), cfg), 0); len(result.Acronym) != 3 {
+		t.Errorf("Acronym = %q with -pronounceable, want three letters", result.Acronym)
+	}
+}
+
+func TestWriteBulk_ZeroCount(t *testing.T) {
+	g := New(testWordSet(), testConfig("startup", ""))
+
+	var got bytes.Buffer
+	if err := g.WriteBulk(context.Background(), &got, 0, 0, 4); err != nil {
+		t.Fatalf("WriteBulk error: %v", err)
+	}
+	if got.Len() != 0 {
+		t.Errorf("expected no output, got %q", got.String())
+	}
+}
+
+// failingWriter accepts a fixed number of writes and then fails.
+type failingWriter struct {
+	left int
+}
+
+var errWriteFailed = errors.New("write failed")
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.left == 0 {
+		return 0, errWriteFailed
+	}
+	w.left--
+	return len(p), nil
+}
+
+func TestWriteBulk_StopsOnWriteError(t *testing.T) {
+	g := New(testWordSet(), testConfig("startup", ""))
+
+	err := g.WriteBulk(context.Background(), &failingWriter{left: 1}, 0, bulkChunkSize*20, 4)
+	if !errors.Is(err, errWriteFailed) {
+		t.Errorf("err = %v, want %v", err, errWriteFailed)
+	}
+}
+
+func TestWriteBulk_Cancelled(t *testing.T) {
+	g := New(testWordSet(), testConfig("startup", ""))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var got bytes.Buffer
+	err := g.WriteBulk(ctx, &got, 0, bulkChunkSize*20, 4)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want %v", err, context.Canceled)
+	}
+	if got.Len() != 0 {
+		t.Errorf("expected no output after cancellation, got %d bytes", got.Len())
+	}
+}
@@ -0,0 +1,382 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"fn-gen/internal/grammar"
+	"fn-gen/internal/words"
+)
+
+// ErrUnsatisfiable is returned when no name of the word pack can meet the
+// configured constraints, whatever the seed.
+var ErrUnsatisfiable = errors.New("no name can meet the constraints")
+
+// DefaultAlphabet is the alphabet -sequence advances through unless
+// -alphabet sets another.
+const DefaultAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// constraints holds the -alliterate, -starts-with, -sequence,
+// -max-syllables, length and acronym settings prepared for the generator's
+// pattern. A nil *constraints allows every name.
+//
+// Alliteration, the prefix, the sequence letter and the acronym dictionary
+// narrow the word lists up front rather than waiting for a random
+// candidate to comply, which would rarely happen within MaxAttempts: each
+// candidate first draws a key, one of the initials that every position can
+// supply or one of the dictionary words the positions can spell, then
+// draws its words from the lists filtered for that key. The syllable and
+// length limits and pronounceability, which depend on the whole name, are
+// checked on the composed candidate.
+type constraints struct {
+	alliterate    bool              // All words share their initial
+	bySound       bool              // Initials are opening sounds rather than letters
+	startsWith    string            // -starts-with as given, for messages
+	prefix        string            // Folded -starts-with prefix ("" = any)
+	sequence      int               // -sequence number, 1 for the first letter (0 = off)
+	alphabet      []string          // Folded letters -sequence advances through
+	initial       string            // Folded letter -sequence selected ("" = any)
+	skipped       []string          // Letters of the alphabet no name starts with
+	maxSyllables  int               // Maximum syllables of the name (0 = no limit)
+	lang          string            // Language for counting syllables
+	minLength     int               // Minimum characters of the name after casing (0 = no limit)
+	maxLength     int               // Maximum characters of the name after casing (0 = no limit)
+	casing        grammar.Case      // Output case the length is measured in
+	pronounceable bool              // The acronym must read as a word
+	dict          *words.Dictionary // Words the acronym must be one of (nil: any)
+
+	keys  []string              // Initials every position can supply (alliteration) or dictionary words they can spell, sorted
+	lists map[string][][]string // Allowed words per pattern position, by key ("" without keys)
+	err   error                 // Why no name can meet the constraints
+}
+
+// newConstraints prepares the constraints configured in g.cfg, or returns
+// nil if there are none. It must run after the options are applied, as
+// qualified pattern keys draw from the packs given by WithLanguages and
+// the acronym dictionary comes from WithDictionary.
+func (g *Generator) newConstraints(lang string) *constraints {
+	cfg := g.cfg
+	if cfg.Alliterate == "" && cfg.StartsWith == "" && cfg.Sequence <= 0 && cfg.MaxSyllables <= 0 && cfg.MinLength <= 0 && cfg.MaxLength <= 0 &&
+		!cfg.Pronounceable && g.dict == nil {
+		return nil
+	}
+	c := &constraints{
+		alliterate:    cfg.Alliterate != "",
+		bySound:       cfg.Alliterate == "sound",
+		startsWith:    cfg.StartsWith,
+		prefix:        grammar.Fold(cfg.StartsWith),
+		sequence:      max(cfg.Sequence, 0),
+		maxSyllables:  max(cfg.MaxSyllables, 0),
+		lang:          lang,
+		minLength:     max(cfg.MinLength, 0),
+		maxLength:     max(cfg.MaxLength, 0),
+		casing:        g.casing,
+		pronounceable: cfg.Pronounceable,
+		dict:          g.dict,
+	}
+	if c.sequence > 0 {
+		if c.alphabet, c.err = parseAlphabet(cfg.Alphabet); c.err != nil {
+			return c
+		}
+	}
+	if c.maxLength > 0 && c.minLength > c.maxLength {
+		c.err = fmt.Errorf("%w: -min-length %d is greater than -max-length %d", ErrUnsatisfiable, c.minLength, c.maxLength)
+		return c
+	}
+
+	// Backronyms draw their own words, one per letter of the target (see
+	// backronym.go); only the checks on the whole name apply
+	if g.back != nil {
+		return c
+	}
+
+	// Word lists of the positions that have words, and the position whose
+	// word the composer puts first
+	base := make([][]string, len(g.pattern))
+	var positions []int
+	var categories []string
+	for i, key := range g.pattern {
+		base[i] = g.positionList(i)
+		if len(base[i]) > 0 {
+			_, category := g.source(key)
+			positions = append(positions, i)
+			categories = append(categories, category)
+		}
+	}
+	if len(positions) == 0 {
+		c.err = fmt.Errorf("%w: the pattern has no words", ErrUnsatisfiable)
+		return c
+	}
+
+	// The prefix narrows the leading position; a prefix longer than one
+	// word ("Bold B") must at least begin with the whole leading word
+	if c.prefix != "" {
+		lead := positions[g.composer.Order(categories)[0]]
+		base[lead] = filterWords(base[lead], func(w string) bool {
+			folded := grammar.Fold(w)
+			return strings.HasPrefix(folded, c.prefix) || strings.HasPrefix(c.prefix, folded+" ")
+		})
+		if len(base[lead]) == 0 {
+			c.err = fmt.Errorf("%w: no word in %s starts with %q", ErrUnsatisfiable, g.pattern[lead], c.startsWith)
+			return c
+		}
+	}
+
+	// -sequence narrows the leading position to one initial: the N-th
+	// letter of the alphabet among those some name can start with
+	if c.sequence > 0 {
+		lead := positions[g.composer.Order(categories)[0]]
+		var usable []string
+		for _, letter := range c.alphabet {
+			narrowed := slices.Clone(base)
+			narrowed[lead] = filterWords(base[lead], func(w string) bool { return grammar.Initial(w, false) == letter })
+			if len(narrowed[lead]) > 0 && c.narrow(g, narrowed, positions, categories) == nil {
+				usable = append(usable, letter)
+			}
+		}
+		if len(usable) == 0 {
+			c.err = fmt.Errorf("%w: no name starts with a letter of %s", ErrUnsatisfiable, strings.ToUpper(strings.Join(c.alphabet, "")))
+			return c
+		}
+
+		// Past the last usable letter the alphabet starts over
+		c.initial = usable[(c.sequence-1)%len(usable)]
+		for _, letter := range c.alphabet {
+			if !slices.Contains(usable, letter) {
+				c.skipped = append(c.skipped, letter)
+			}
+		}
+		base[lead] = filterWords(base[lead], func(w string) bool { return grammar.Initial(w, false) == c.initial })
+	}
+
+	if c.err = c.narrow(g, base, positions, categories); c.err != nil {
+		return c
+	}
+
+	// The shortest possible name must fit the syllable and length limits.
+	// Letters and digits alone bound the length from below, whatever the
+	// case, word order or compounding add
+	if c.maxSyllables > 0 || c.maxLength > 0 {
+		syllables, length := -1, -1
+		for _, lists := range c.lists {
+			ns, nl := 0, 0
+			for _, i := range positions {
+				ns += slices.Min(syllableCounts(lists[i], lang))
+				nl += slices.Min(letterCounts(lists[i]))
+			}
+			if syllables < 0 || ns < syllables {
+				syllables = ns
+			}
+			if length < 0 || nl < length {
+				length = nl
+			}
+		}
+		switch {
+		case c.maxSyllables > 0 && syllables > c.maxSyllables:
+			c.err = fmt.Errorf("%w: the shortest names have %d syllables, more than %d", ErrUnsatisfiable, syllables, c.maxSyllables)
+		case c.maxLength > 0 && length > c.maxLength:
+			c.err = fmt.Errorf("%w: the shortest names have at least %d characters, more than %d", ErrUnsatisfiable, length, c.maxLength)
+		}
+	}
+	return c
+}
+
+// narrow sets the keys and the word lists per key that candidates draw
+// from, given the allowed words base of every pattern position. positions
+// are the positions that have words and categories their categories. It
+// returns why no name can be drawn from base, if so.
+func (c *constraints) narrow(g *Generator, base [][]string, positions []int, categories []string) error {
+	switch {
+	case c.dict != nil:
+		// Only dictionary words with one letter per position, each the
+		// initial of a word of that position, are usable; the letters
+		// follow the order in which the composer puts the words
+		order := g.composer.Order(categories)
+		c.keys, c.lists = nil, make(map[string][][]string)
+		for _, word := range c.dict.Words() {
+			key := strings.ToUpper(grammar.Fold(word))
+			letters := strings.Split(key, "")
+			if len(letters) != len(positions) || c.lists[key] != nil {
+				continue
+			}
+			lists := slices.Clone(base)
+			for j, letter := range letters {
+				i := positions[order[j]]
+				if lists[i] = filterWords(base[i], func(w string) bool { return grammar.Acronym(w) == letter }); len(lists[i]) == 0 {
+					lists = nil
+					break
+				}
+			}
+			if lists != nil {
+				c.lists[key] = lists
+				c.keys = append(c.keys, key)
+			}
+		}
+		slices.Sort(c.keys)
+		if len(c.keys) == 0 {
+			return fmt.Errorf("%w: no word in %s is spelt by the initials of the pattern's words", ErrUnsatisfiable, c.dict.Source())
+		}
+
+	case c.alliterate:
+		// Only initials that every position can supply are usable
+		var shared map[string]bool
+		for _, i := range positions {
+			here := make(map[string]bool)
+			for _, w := range base[i] {
+				if initial := grammar.Initial(w, c.bySound); initial != "" && (shared == nil || shared[initial]) {
+					here[initial] = true
+				}
+			}
+			shared = here
+		}
+		c.keys = slices.Sorted(maps.Keys(shared))
+		if len(c.keys) == 0 {
+			return fmt.Errorf("%w: no initial %s is shared by a word of every category in the pattern", ErrUnsatisfiable, c.unit())
+		}
+
+		c.lists = make(map[string][][]string, len(c.keys))
+		for _, initial := range c.keys {
+			lists := make([][]string, len(base))
+			for i, list := range base {
+				lists[i] = filterWords(list, func(w string) bool { return grammar.Initial(w, c.bySound) == initial })
+			}
+			c.lists[initial] = lists
+		}
+
+	default:
+		c.keys, c.lists = nil, map[string][][]string{"": base}
+	}
+	return nil
+
+}
+
+// draw returns the word lists for the next candidate. With alliteration or
+// a dictionary it draws the candidate's key from stream first.
+func (c *constraints) draw(stream *Stream) [][]string {
+	if len(c.keys) == 0 {
+		return c.lists[""]
+	}
+	return c.lists[c.keys[stream.Next()%uint64(len(c.keys))]]
+}
+
+// check returns why a composed name does not meet the constraints, or ""
+// if it does. selected are the words of the name in pattern order.
+func (c *constraints) check(name string, selected []grammar.Word) string {
+	if c == nil {
+		return ""
+	}
+	if c.alliterate && len(selected) > 0 {
+		want := grammar.Initial(selected[0].Text, c.bySound)
+		for _, w := range selected[1:] {
+			if grammar.Initial(w.Text, c.bySound) != want {
+				return fmt.Sprintf("%q and %q do not share their initial %s", selected[0].Text, w.Text, c.unit())
+			}
+		}
+	}
+	if c.prefix != "" && !strings.HasPrefix(grammar.Fold(name), c.prefix) {
+		return fmt.Sprintf("does not start with %q", c.startsWith)
+	}
+	if c.initial != "" && grammar.Initial(name, false) != c.initial {
+		return fmt.Sprintf("does not start with %s, letter %d of the sequence", strings.ToUpper(c.initial), c.sequence)
+	}
+	if c.maxSyllables > 0 {
+		if n := grammar.Syllables(name, c.lang); n > c.maxSyllables {
+			return fmt.Sprintf("%d syllables, more than %d", n, c.maxSyllables)
+		}
+	}
+	if c.pronounceable || c.dict != nil {
+		acronym := grammar.Acronym(name)
+		if c.pronounceable && !grammar.Pronounceable(acronym) {
+			return fmt.Sprintf("acronym %q is not pronounceable", acronym)
+		}
+		if c.dict != nil && c.lists[acronym] == nil {
+			return fmt.Sprintf("acronym %q is not in %s", acronym, c.dict.Source())
+		}
+	}
+	if c.minLength > 0 || c.maxLength > 0 {
+		// Length counts characters of the name as it is printed
+		cased := c.casing.Apply(name)
+		n := utf8.RuneCountInString(cased)
+		as := ""
+		if cased != name {
+			as = fmt.Sprintf(" as %q", cased)
+		}
+		switch {
+		case c.maxLength > 0 && n > c.maxLength:
+			return fmt.Sprintf("%d characters%s, more than %d", n, as, c.maxLength)
+		case n < c.minLength:
+			return fmt.Sprintf("%d characters%s, fewer than %d", n, as, c.minLength)
+		}
+	}
+	return ""
+}
+
+// sequenceLetter returns the letter -sequence selected, upper case, or ""
+// without -sequence.
+func (c *constraints) sequenceLetter() string {
+	if c == nil {
+		return ""
+	}
+	return strings.ToUpper(c.initial)
+}
+
+// parseAlphabet returns the folded letters of a -alphabet, in order. Each
+// letter or digit may appear once; case and accents do not count.
+func parseAlphabet(alphabet string) ([]string, error) {
+	if alphabet == "" {
+		alphabet = DefaultAlphabet
+	}
+	var letters []string
+	for _, r := range grammar.Fold(alphabet) {
+		letter := string(r)
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			return nil, fmt.Errorf("alphabet %q must consist of letters and digits only", alphabet)
+		case slices.Contains(letters, letter):
+			return nil, fmt.Errorf("alphabet %q repeats %s", alphabet, strings.ToUpper(letter))
+		}
+		letters = append(letters, letter)
+	}
+	return letters, nil
+}
+
+// unit names what alliterates, for messages.
+func (c *constraints) unit() string {
+	if c.bySound {
+		return "sound"
+	}
+	return "letter"
+}
+
+// filterWords returns the words of list for which keep reports true.
+func filterWords(list []string, keep func(string) bool) []string {
+	return slices.DeleteFunc(slices.Clone(list), func(w string) bool { return !keep(w) })
+}
+
+// letterCounts returns the number of letters and digits of every word in
+// list.
+func letterCounts(list []string) []int {
+	counts := make([]int, len(list))
+	for i, w := range list {
+		for _, r := range w {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				counts[i]++
+			}
+		}
+	}
+	return counts
+}
+
+// syllableCounts returns the syllable count of every word in list.
+func syllableCounts(list []string, lang string) []int {
+	counts := make([]int, len(list))
+	for i, w := range list {
+		counts[i] = grammar.Syllables(w, lang)
+	}
+	return counts
+}
@@ -0,0 +1,292 @@
+package generator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"fn-gen/internal/cli"
+	"fn-gen/internal/grammar"
+	"fn-gen/internal/words"
+)
+
+// alliterationWordSet has exactly one initial, B, shared by every category.
+func alliterationWordSet() words.WordSet {
+	return words.WordSet{
+		Adjectives: []string{"Smart", "Fast", "Bold", "Quick"},
+		Core:       []string{"Engine", "Blockchain", "Gateway", "Data"},
+		Suffix:     []string{"Hub", "Bridge", "Plus"},
+	}
+}
+
+func TestConstraints_Alliterate(t *testing.T) {
+	cfg := testConfig("startup", "")
+	cfg.Alliterate = "letter"
+	g := New(alliterationWordSet(), cfg)
+
+	for i := range 20 {
+		if name := mustGenerate(t, g, i); name != "Bold Blockchain Bridge" {
+			t.Errorf("index %d: got %q, want the only alliterating name", i, name)
+		}
+	}
+}
+
+func TestConstraints_AlliterateBySound(t *testing.T) {
+	ws := words.WordSet{
+		Adjectives: []string{"Cloud-Native", "Fast"},
+		Core:       []string{"Kafka", "Data"},
+		Suffix:     []string{"Core", "Hub"},
+	}
+	cfg := testConfig("startup", "sound")
+	cfg.Alliterate = "letter"
+	if _, err := New(ws, cfg).Generate(0); !errors.Is(err, ErrUnsatisfiable) {
+		t.Fatalf("letter alliteration: error = %v, want ErrUnsatisfiable", err)
+	}
+
+	cfg.Alliterate = "sound"
+	name := mustGenerate(t, New(ws, cfg), 0)
+	if name != "Cloud-Native Kafka Core" {
+		t.Errorf("got %q, want the C/K alliteration", name)
+	}
+}
+
+func TestConstraints_StartsWith(t *testing.T) {
+	cfg := testConfig("startup", "")
+	cfg.StartsWith = "fa"
+	g := New(testWordSet(), cfg)
+	for i := range 20 {
+		if name := mustGenerate(t, g, i); !strings.HasPrefix(name, "Fast ") {
+			t.Errorf("index %d: %q does not start with %q", i, name, cfg.StartsWith)
+		}
+	}
+
+	// Romance names start with the head noun, not the adjective
+	ws := words.WordSet{
+		Meta:       words.Meta{Language: "fr"},
+		Adjectives: []string{"Agile", "Simple"},
+		Core:       []string{"Flux", "Donnée"},
+		Suffix:     []string{"Moteur", "Plateforme"},
+	}
+	cfg.StartsWith = "pl"
+	if name := mustGenerate(t, New(ws, cfg), 0); !strings.HasPrefix(name, "Plateforme de ") {
+		t.Errorf("got %q, want it to start with Plateforme", name)
+	}
+}
+
+func TestConstraints_Sequence(t *testing.T) {
+	// The adjectives start with B, F, Q and S; the other letters are skipped
+	tests := []struct {
+		sequence int
+		alphabet string
+		want     string
+	}{
+		{1, "", "B"},
+		{2, "", "F"},
+		{4, "", "S"},
+		{5, "", "B"}, // Past the last letter the alphabet starts over
+		{2, "sxf", "F"},
+		{3, "sxf", "S"},
+	}
+	for _, tt := range tests {
+		cfg := testConfig("startup", "")
+		cfg.Sequence, cfg.Alphabet = tt.sequence, tt.alphabet
+		g := New(alliterationWordSet(), cfg)
+		for i := range 10 {
+			result := mustExplain(t, g, i)
+			if !strings.HasPrefix(result.Name, tt.want) || result.Initial != tt.want {
+				t.Errorf("-sequence %d -alphabet %q: got %q (initial %q), want it to start with %s",
+					tt.sequence, tt.alphabet, result.Name, result.Initial, tt.want)
+			}
+		}
+	}
+
+	cfg := testConfig("startup", "release")
+	cfg.Sequence = 2
+	if a, b := mustGenerate(t, New(alliterationWordSet(), cfg), 0), mustGenerate(t, New(alliterationWordSet(), cfg), 0); a != b {
+		t.Errorf("same seed gave %q and %q", a, b)
+	}
+	if skipped := New(alliterationWordSet(), cfg).SkippedLetters(); len(skipped) != 22 || skipped[0] != "A" || skipped[1] != "C" {
+		t.Errorf("SkippedLetters() = %v, want the 22 letters without names", skipped)
+	}
+
+	// Only letters every category alliterates on count
+	cfg.Alliterate = "letter"
+	if name := mustGenerate(t, New(alliterationWordSet(), cfg), 0); name != "Bold Blockchain Bridge" {
+		t.Errorf("with alliteration: got %q, want the only alliterating name", name)
+	}
+}
+
+func TestConstraints_SequenceRekeysReleases(t *testing.T) {
+	// Each release number keys its own stream, so consecutive releases of
+	// one seed differ in more than their first word
+	cfg := testConfig("startup", "release")
+	var prev []ExplainedPart
+	for n := 1; n <= 4; n++ {
+		cfg.Sequence = n
+		parts := mustExplain(t, New(alliterationWordSet(), cfg), 0).Parts
+		if prev != nil && parts[1].Word == prev[1].Word && parts[2].Word == prev[2].Word {
+			t.Errorf("-sequence %d keeps the words %q and %q of the release before", n, parts[1].Word, parts[2].Word)
+		}
+		prev = parts
+	}
+}
+
+func TestConstraints_MaxSyllables(t *testing.T) {
+	cfg := testConfig("startup", "")
+	cfg.MaxSyllables = 4
+	g := New(testWordSet(), cfg)
+	for i := range 20 {
+		result := mustExplain(t, g, i)
+		if n := grammar.Syllables(result.Name, "en"); n > 4 {
+			t.Errorf("%q has %d syllables", result.Name, n)
+		}
+		for _, r := range result.Rejected {
+			if r.Cause != Constrained || !strings.Contains(r.Reason, "syllables, more than 4") {
+				t.Errorf("unexpected rejection %+v", r)
+			}
+		}
+	}
+	if g.Rejections(Constrained) == 0 {
+		t.Error("expected some candidates to be rejected as too long")
+	}
+}
+
+func TestConstraints_Length(t *testing.T) {
+	cfg := testConfig("startup", "")
+	cfg.Case = "kebab"
+	cfg.MaxLength = 16
+	g := New(testWordSet(), cfg)
+	for i := range 20 {
+		result := mustExplain(t, g, i)
+		if len(result.Name) > 16 || result.Name != strings.ToLower(result.Name) {
+			t.Errorf("got %q, want a kebab-case name of at most 16 characters", result.Name)
+		}
+		for _, r := range result.Rejected {
+			if r.Cause != Constrained || !strings.Contains(r.Reason, "characters as") {
+				t.Errorf("unexpected rejection %+v", r)
+			}
+		}
+	}
+	if g.Rejections(Constrained) == 0 {
+		t.Error("expected some candidates to be rejected as too long")
+	}
+
+	cfg.MaxLength, cfg.MinLength = 0, 18
+	g = New(testWordSet(), cfg)
+	for i := range 20 {
+		if name := mustGenerate(t, g, i); len(name) < 18 {
+			t.Errorf("got %q, want at least 18 characters", name)
+		}
+	}
+}
+
+func TestConstraints_CaseKeepsDerivation(t *testing.T) {
+	plain := New(testWordSet(), testConfig("startup", "stable"))
+	cfg := testConfig("startup", "stable")
+	cfg.Case = "kebab"
+	kebab := New(testWordSet(), cfg)
+	for i := range 10 {
+		want := grammar.CaseKebab.Apply(mustGenerate(t, plain, i))
+		if got := mustGenerate(t, kebab, i); got != want {
+			t.Errorf("index %d: got %q, want %q", i, got, want)
+		}
+	}
+}
+
+func TestConstraints_Pronounceable(t *testing.T) {
+	cfg := testConfig("startup", "")
+	cfg.Pronounceable = true
+	g := New(testWordSet(), cfg)
+	for i := range 20 {
+		if name := mustGenerate(t, g, i); !grammar.Pronounceable(grammar.Acronym(name)) {
+			t.Errorf("%q has an unpronounceable acronym", name)
+		}
+	}
+}
+
+func TestConstraints_Dictionary(t *testing.T) {
+	// BEG and FOO cannot be spelt: no suffix starts with G, no core with O
+	dict, err := words.ParseDictionary("dict.txt", strings.NewReader("sep\nFGH\nBEG\nFOO\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := New(testWordSet(), testConfig("startup", ""), WithDictionary(dict))
+	seen := make(map[string]bool)
+	for i := range 20 {
+		seen[grammar.Acronym(mustGenerate(t, g, i))] = true
+	}
+	if len(seen) != 2 || !seen["SEP"] || !seen["FGH"] {
+		t.Errorf("got acronyms %v, want SEP and FGH", seen)
+	}
+
+	dict, _ = words.ParseDictionary("dict.txt", strings.NewReader("FOO\n"))
+	_, err = New(testWordSet(), testConfig("startup", "x"), WithDictionary(dict)).Generate(0)
+	if !errors.Is(err, ErrUnsatisfiable) || !strings.Contains(err.Error(), "no word in dict.txt") {
+		t.Errorf("error = %v, want ErrUnsatisfiable naming the dictionary", err)
+	}
+}
+
+func TestConstraints_Unsatisfiable(t *testing.T) {
+	tests := []struct {
+		name   string
+		adjust func(*cli.Config)
+		want   string
+	}{
+		{"prefix", func(c *cli.Config) { c.StartsWith = "Z" }, `no word in adjectives starts with "Z"`},
+		{"alliteration", func(c *cli.Config) { c.Alliterate, c.StartsWith = "letter", "S" }, "no initial letter is shared"},
+		{"syllables", func(c *cli.Config) { c.MaxSyllables = 3 }, "the shortest names have 4 syllables, more than 3"},
+		{"length", func(c *cli.Config) { c.MaxLength = 12 }, "the shortest names have at least 13 characters, more than 12"},
+		{"min above max", func(c *cli.Config) { c.MinLength, c.MaxLength = 10, 5 }, "-min-length 10 is greater than -max-length 5"},
+		{"sequence", func(c *cli.Config) { c.Sequence, c.Alphabet = 1, "XYZ" }, "no name starts with a letter of XYZ"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig("startup", "x")
+			tt.adjust(&cfg)
+
+			_, err := New(testWordSet(), cfg).Generate(0)
+			if !errors.Is(err, ErrUnsatisfiable) || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want ErrUnsatisfiable containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestConstraints_InvalidAlphabet(t *testing.T) {
+	for alphabet, want := range map[string]string{"ABA": "repeats A", "A-C": "letters and digits only"} {
+		cfg := testConfig("startup", "x")
+		cfg.Sequence, cfg.Alphabet = 1, alphabet
+		if _, err := New(testWordSet(), cfg).Generate(0); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("-alphabet %q: error = %v, want it to contain %q", alphabet, err, want)
+		}
+	}
+}
+
+func TestConstraints_UnconstrainedNamesUnchanged(t *testing.T) {
+	plain := New(testWordSet(), testConfig("startup", "stable"))
+
+	// A limit every name meets must not change the derivation
+	cfg := testConfig("startup", "stable")
+	cfg.MaxSyllables = 100
+	limited := New(testWordSet(), cfg)
+	for i := range 20 {
+		if a, b := mustGenerate(t, plain, i), mustGenerate(t, limited, i); a != b {
+			t.Errorf("index %d: %q became %q", i, a, b)
+		}
+	}
+}
+
+func TestConstraints_EnumerateSkips(t *testing.T) {
+	cfg := testConfig("startup", "")
+	cfg.Alliterate = "letter"
+	var names []string
+	for name, err := range New(alliterationWordSet(), cfg).Enumerate(t.Context(), 0, 0) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, name)
+	}
+	if len(names) != 1 || names[0] != "Bold Blockchain Bridge" {
+		t.Errorf("got %v, want only the alliterating name", names)
+	}
+}
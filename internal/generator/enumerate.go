@@ -0,0 +1,124 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"math/bits"
+
+	"fn-gen/internal/grammar"
+)
+
+// ErrTooManyCombinations is returned when the size of the combination space
+// does not fit into a uint64.
+var ErrTooManyCombinations = errors.New("combination count overflows uint64")
+
+// Combinations returns the total number of names the configured pattern can
+// produce, i.e. the product of the word list sizes of every position.
+// Empty categories are skipped, exactly as GenerateExplained skips them;
+// a pattern without any words has no combinations. Positions pinned by
+// -fix count once.
+func (g *Generator) Combinations() (uint64, error) {
+	if g.fixErr != nil {
+		return 0, g.fixErr
+	}
+	_, lists := g.lists()
+	if len(lists) == 0 {
+		return 0, nil
+	}
+
+	total := uint64(1)
+	for _, list := range lists {
+		hi, lo := bits.Mul64(total, uint64(len(list)))
+		if hi != 0 {
+			return 0, ErrTooManyCombinations
+		}
+		total = lo
+	}
+	return total, nil
+}
+
+// Enumerate returns an iterator over every name in the pattern's combination
+// space, skipping the first offset names and yielding at most limit names
+// (limit 0 means no limit).
+//
+// The order is the Cartesian product of the category lists taken in pattern
+// order, with the last position varying fastest, like an odometer:
+//
+//	Smart Engine, Smart Pipeline, ..., Fast Engine, Fast Pipeline, ...
+//
+// Combination number k is therefore stable for a given word pack, which makes
+// offset/limit usable as page boundaries. Names rejected by the blocklist,
+// the exclude list or the constraints (-alliterate and friends) are
+// skipped but still count towards offset and limit, so the pages stay the
+// same when those lists change; a page may then hold fewer names.
+// Cancellation is reported as a final ("", ctx.Err()) pair.
+func (g *Generator) Enumerate(ctx context.Context, offset, limit uint64) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		total, err := g.Combinations()
+		if err != nil {
+			yield("", err)
+			return
+		}
+		if offset >= total {
+			return
+		}
+
+		remaining := total - offset
+		if limit > 0 && limit < remaining {
+			remaining = limit
+		}
+
+		// Decode the offset into per-position indices (mixed radix,
+		// last position least significant)
+		keys, lists := g.lists()
+		digits := make([]int, len(lists))
+		rest := offset
+		for i := len(lists) - 1; i >= 0; i-- {
+			size := uint64(len(lists[i]))
+			digits[i] = int(rest % size)
+			rest /= size
+		}
+
+		selected := make([]grammar.Word, len(lists))
+		for ; remaining > 0; remaining-- {
+			if err := ctx.Err(); err != nil {
+				yield("", err)
+				return
+			}
+
+			for i, list := range lists {
+				word := list[digits[i]]
+				ws, category := g.source(keys[i])
+				selected[i] = grammar.Word{Category: category, Text: word, Features: ws.Features(word)}
+			}
+			if name := g.composer.Compose(selected); g.acceptable(name, selected) {
+				if !yield(g.line(g.casing.Apply(name), g.acronym(name)), nil) {
+					return
+				}
+			}
+
+			// Advance the odometer
+			for i := len(digits) - 1; i >= 0; i-- {
+				digits[i]++
+				if digits[i] < len(lists[i]) {
+					break
+				}
+				digits[i] = 0
+			}
+		}
+	}
+}
+
+// lists returns the non-empty word lists of the configured pattern in
+// order, together with their category keys. A position pinned by -fix
+// has its fixed word only.
+func (g *Generator) lists() (keys []string, lists [][]string) {
+	for i, key := range g.pattern {
+		if list := g.positionList(i); len(list) > 0 {
+			keys = append(keys, key)
+			lists = append(lists, list)
+		}
+	}
+	return keys, lists
+}
@@ -0,0 +1,131 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"fn-gen/internal/words"
+)
+
+func collect(t *testing.T, seq func(func(string, error) bool)) []string {
+	t.Helper()
+	var names []string
+	for name, err := range seq {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestCombinations_ProductOfListSizes(t *testing.T) {
+	tests := []struct {
+		mode string
+		want uint64
+	}{
+		{"minimal", 9},
+		{"startup", 27},
+		{"enterprise", 81},
+		{"bullshit", 243},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			g := New(testWordSet(), testConfig(tt.mode, ""))
+			got, err := g.Combinations()
+			if err != nil {
+				t.Fatalf("Combinations error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Combinations() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCombinations_EmptyPattern(t *testing.T) {
+	g := New(words.WordSet{}, testConfig("startup", ""))
+
+	got, err := g.Combinations()
+	if err != nil || got != 0 {
+		t.Errorf("Combinations() = %d, %v; want 0, nil", got, err)
+	}
+	if names := collect(t, g.Enumerate(context.Background(), 0, 0)); len(names) != 0 {
+		t.Errorf("expected no names, got %v", names)
+	}
+}
+
+func TestCombinations_Overflow(t *testing.T) {
+	huge := make([]string, 1<<16)
+	ws := words.WordSet{Adjectives: huge, Buzzwords: huge, Core: huge, Suffix: huge}
+	g := New(ws, testConfig("bullshit", ""))
+
+	if _, err := g.Combinations(); !errors.Is(err, ErrTooManyCombinations) {
+		t.Errorf("err = %v, want %v", err, ErrTooManyCombinations)
+	}
+}
+
+func TestEnumerate_OdometerOrder(t *testing.T) {
+	g := New(testWordSet(), testConfig("minimal", ""))
+
+	got := collect(t, g.Enumerate(context.Background(), 0, 4))
+	want := []string{"Smart Engine", "Smart Pipeline", "Smart Gateway", "Fast Engine"}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestEnumerate_AllDistinct(t *testing.T) {
+	g := New(testWordSet(), testConfig("startup", ""))
+
+	names := collect(t, g.Enumerate(context.Background(), 0, 0))
+	if len(names) != 27 {
+		t.Fatalf("got %d names, want 27", len(names))
+	}
+
+	seen := make(map[string]bool)
+	for _, n := range names {
+		if seen[n] {
+			t.Errorf("duplicate name %q", n)
+		}
+		seen[n] = true
+	}
+}
+
+func TestEnumerate_Paging(t *testing.T) {
+	g := New(testWordSet(), testConfig("startup", ""))
+	all := collect(t, g.Enumerate(context.Background(), 0, 0))
+
+	var paged []string
+	for offset := uint64(0); offset < 27; offset += 5 {
+		paged = append(paged, collect(t, g.Enumerate(context.Background(), offset, 5))...)
+	}
+
+	if !slices.Equal(paged, all) {
+		t.Errorf("paged enumeration differs from full enumeration")
+	}
+}
+
+func TestEnumerate_OffsetPastEnd(t *testing.T) {
+	g := New(testWordSet(), testConfig("minimal", ""))
+
+	if names := collect(t, g.Enumerate(context.Background(), 9, 0)); len(names) != 0 {
+		t.Errorf("expected no names past the end, got %v", names)
+	}
+}
+
+func TestEnumerate_Cancelled(t *testing.T) {
+	g := New(testWordSet(), testConfig("bullshit", ""))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, err := range g.Enumerate(ctx, 0, 0) {
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("err = %v, want %v", err, context.Canceled)
+		}
+	}
+}
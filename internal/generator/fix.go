@@ -0,0 +1,73 @@
+package generator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// newFixed resolves the -fix entries to the pattern positions they pin.
+// An entry is "category=Word", pinning the first position of that pattern
+// key that no earlier entry pinned, or "N=Word", pinning position N of the
+// pattern (counting from 1):
+//
+//	-fix adjectives=Dynamic -fix suffix=Hub      → Dynamic … Hub
+//	-fix buzzwords=AI -fix buzzwords=Cloud       → both buzzwords of bullshit mode
+//	-fix 3=Cloud                                 → only the second buzzword
+//
+// A word the category lists is spelt as the list spells it, so that it
+// keeps its grammar features; other words are used as given.
+func (g *Generator) newFixed(entries []string) (map[int]string, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	fixed := make(map[int]string, len(entries))
+	for _, entry := range entries {
+		key, word, ok := strings.Cut(entry, "=")
+		key, word = strings.TrimSpace(key), strings.TrimSpace(word)
+		if !ok || key == "" || word == "" {
+			return nil, fmt.Errorf("-fix %q must be category=Word or position=Word", entry)
+		}
+
+		pos := -1
+		if n, err := strconv.Atoi(key); err == nil {
+			if n < 1 || n > len(g.pattern) {
+				return nil, fmt.Errorf("-fix %q: the pattern %v has no position %d", entry, g.pattern, n)
+			}
+			if _, taken := fixed[n-1]; taken {
+				return nil, fmt.Errorf("-fix %q: position %d is already fixed", entry, n)
+			}
+			pos = n - 1
+		} else {
+			for i, k := range g.pattern {
+				if _, taken := fixed[i]; k == key && !taken {
+					pos = i
+					break
+				}
+			}
+			if pos < 0 {
+				return nil, fmt.Errorf("-fix %q: the pattern %v has no free %s position", entry, g.pattern, key)
+			}
+		}
+
+		// Take the pack's spelling of the word, if it lists it
+		for _, w := range g.list(g.pattern[pos]) {
+			if strings.EqualFold(w, word) {
+				word = w
+				break
+			}
+		}
+		fixed[pos] = word
+	}
+	return fixed, nil
+}
+
+// positionList returns the word list of pattern position i: the fixed
+// word alone if -fix pins it, else the list of its key.
+func (g *Generator) positionList(i int) []string {
+	if word, ok := g.fixed[i]; ok {
+		return []string{word}
+	}
+	return g.list(g.pattern[i])
+}
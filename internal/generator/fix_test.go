@@ -0,0 +1,129 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFix_PinsPositions(t *testing.T) {
+	cfg := testConfig("startup", "")
+	cfg.Fix = []string{"adjectives=Dynamic", "suffix=hub"}
+	g := New(testWordSet(), cfg)
+	plain := New(testWordSet(), testConfig("startup", ""))
+
+	cores := make(map[string]bool)
+	for i := range 30 {
+		result := mustExplain(t, g, i)
+		if !strings.HasPrefix(result.Name, "Dynamic ") || !strings.HasSuffix(result.Name, " Hub") {
+			t.Fatalf("index %d: %q does not keep the fixed words", i, result.Name)
+		}
+		if !result.Parts[0].Locked || result.Parts[1].Locked || !result.Parts[2].Locked {
+			t.Errorf("index %d: parts not marked as fixed: %+v", i, result.Parts)
+		}
+
+		// The free position draws what it draws without -fix
+		if core := result.Parts[1].Word; core != mustExplain(t, plain, i).Parts[1].Word {
+			t.Errorf("index %d: core %q differs from the unfixed name", i, core)
+		}
+		cores[result.Parts[1].Word] = true
+	}
+	if len(cores) < 2 {
+		t.Errorf("-count explored only %v for the free position", cores)
+	}
+}
+
+func TestFix_SeedWithCount(t *testing.T) {
+	// A given seed keeps its name first; -count varies the free positions
+	cfg := testConfig("startup", "JIRA-1")
+	cfg.Fix = []string{"suffix=Hub"}
+	g := New(testWordSet(), cfg)
+
+	seen := make(map[string]int)
+	for i := range 4 {
+		name := mustGenerate(t, g, i)
+		if prev, dup := seen[name]; dup {
+			t.Errorf("index %d repeats %q of index %d", i, name, prev)
+		}
+		seen[name] = i
+	}
+
+	plain := testConfig("startup", "JIRA-1")
+	if first, unfixed := mustExplain(t, g, 0), mustExplain(t, New(testWordSet(), plain), 0); first.Parts[1].Word != unfixed.Parts[1].Word {
+		t.Errorf("index 0 core %q differs from the seed's name %q", first.Parts[1].Word, unfixed.Name)
+	}
+}
+
+func TestFix_RepeatedCategories(t *testing.T) {
+	cfg := testConfig("bullshit", "x")
+	cfg.Fix = []string{"buzzwords=AI", "buzzwords=Cloud"}
+	if result := mustExplain(t, New(testWordSet(), cfg), 0); result.Parts[1].Word != "AI" || result.Parts[2].Word != "Cloud" {
+		t.Errorf("got %q, want both buzzwords fixed in order", result.Name)
+	}
+
+	// A position pins only the buzzword at it
+	cfg.Fix = []string{"3=Cloud"}
+	if result := mustExplain(t, New(testWordSet(), cfg), 0); result.Parts[1].Locked || result.Parts[2].Word != "Cloud" {
+		t.Errorf("got %q, want only the second buzzword fixed", result.Name)
+	}
+}
+
+func TestFix_Enumerate(t *testing.T) {
+	cfg := testConfig("startup", "")
+	cfg.Fix = []string{"adjectives=Smart", "suffix=Hub"}
+	g := New(testWordSet(), cfg)
+
+	if total, err := g.Combinations(); err != nil || total != 3 {
+		t.Errorf("Combinations() = %d, %v; want 3", total, err)
+	}
+	var names []string
+	for name, err := range g.Enumerate(context.Background(), 0, 0) {
+		if err != nil {
+			t.Fatalf("Enumerate error: %v", err)
+		}
+		names = append(names, name)
+	}
+	if want := "Smart Engine Hub,Smart Pipeline Hub,Smart Gateway Hub"; strings.Join(names, ",") != want {
+		t.Errorf("Enumerate = %v, want %s", names, want)
+	}
+}
+
+func TestFix_Constraints(t *testing.T) {
+	cfg := testConfig("startup", "")
+	cfg.Alliterate = "letter"
+	cfg.Fix = []string{"adjectives=Bold"}
+	if name := mustGenerate(t, New(alliterationWordSet(), cfg), 0); name != "Bold Blockchain Bridge" {
+		t.Errorf("got %q, want the fixed word to alliterate", name)
+	}
+
+	cfg.Fix = []string{"adjectives=Smart"}
+	if _, err := New(alliterationWordSet(), cfg).Generate(0); !errors.Is(err, ErrUnsatisfiable) {
+		t.Errorf("error = %v, want ErrUnsatisfiable", err)
+	}
+}
+
+func TestFix_Errors(t *testing.T) {
+	tests := []struct {
+		fix  []string
+		want string
+	}{
+		{[]string{"suffix"}, "must be category=Word or position=Word"},
+		{[]string{"suffix="}, "must be category=Word or position=Word"},
+		{[]string{"buzzwords=AI"}, "has no free buzzwords position"},
+		{[]string{"suffix=Hub", "suffix=Pro"}, "has no free suffix position"},
+		{[]string{"4=Hub"}, "has no position 4"},
+		{[]string{"suffix=Hub", "3=Pro"}, "position 3 is already fixed"},
+	}
+	for _, tt := range tests {
+		cfg := testConfig("startup", "x")
+		cfg.Fix = tt.fix
+		g := New(testWordSet(), cfg)
+		if _, err := g.Generate(0); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("-fix %v: error = %v, want it to contain %q", tt.fix, err, tt.want)
+		}
+		if _, err := g.Combinations(); err == nil {
+			t.Errorf("-fix %v: Combinations() returned no error", tt.fix)
+		}
+	}
+}
@@ -1,38 +1,220 @@
 package generator
 
 import (
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
+	"fn-gen/internal/blocklist"
 	"fn-gen/internal/cli"
+	"fn-gen/internal/grammar"
 	"fn-gen/internal/words"
 )
 
+// MaxAttempts is the number of candidates derived for one seed before
+// generation gives up because every one of them was rejected.
+const MaxAttempts = 100
+
+// ErrNoCandidate is returned when all MaxAttempts candidates for a seed
+// were rejected.
+var ErrNoCandidate = errors.New("no acceptable name")
+
+// Generator produces feature names from a word set and configuration.
+//
+// A Generator is immutable after New returns: every method only reads the
+// word set and configuration, and all per-name state lives on the caller's
+// stack. The only exception are the rejection counters, which are updated
+// atomically. It is therefore safe to share a single Generator across
+// goroutines without additional locking.
 type Generator struct {
-	words words.WordSet // Word pools for each category (adjectives, buzzwords, etc.)
-	cfg   cli.Config    // User configuration from CLI flags
+	words   words.WordSet            // Word pools for each category (adjectives, buzzwords, etc.)
+	langs   map[string]words.WordSet // Packs for language-qualified pattern keys, by language tag
+	cfg     cli.Config               // User configuration from CLI flags
+	pattern []string                 // Word category pattern (from -pattern or the mode)
+	key     []string                 // What keys a name's stream besides the seed (see streamKey)
+	date    string                   // Day stamp for automatic seeds, fixed when the generator is created
+
+	composer grammar.Composer // Turns the selected words into a name (word order and agreement of the language)
+	casing   grammar.Case     // Output case applied to every name (-case)
+	cons     *constraints     // Alliteration, prefix, sequence, syllable, length and acronym limits (nil: none)
+	back     *backronym       // Target word each name spells (nil: ordinary names)
+	fixed    map[int]string   // Words -fix pins, by pattern position (nil: none)
+	fixErr   error            // Why the -fix entries do not fit the pattern
+
+	blocked  *blocklist.List     // Names that must not be produced (nil: none)
+	excluded *blocklist.Excludes // Names already in use (nil: none)
+	registry Registry            // Names claimed by seeds (nil: none)
+	dict     *words.Dictionary   // Words acronyms must spell (nil: any)
+
+	rejections [numCauses]atomic.Uint64 // Rejected candidates per cause, across all calls
+}
+
+// Cause is the reason a candidate name was rejected.
+type Cause int
+
+const (
+	Blocked     Cause = iota // Matched a blocklist rule
+	Excluded                 // Already in use (exclude file)
+	Claimed                  // Claimed by another seed in the registry
+	Constrained              // Did not meet -alliterate, -starts-with, -sequence, -max-syllables, a length limit or an acronym filter
+
+	numCauses = iota
+)
+
+func (c Cause) String() string {
+	switch c {
+	case Excluded:
+		return "excluded"
+	case Claimed:
+		return "claimed"
+	case Constrained:
+		return "constrained"
+	default:
+		return "blocked"
+	}
+}
+
+// Registry records which seed claimed which name (see package registry).
+type Registry interface {
+	ClaimedName(seed string) (name string, ok bool)  // Name claimed for seed
+	ClaimingSeed(name string) (seed string, ok bool) // Seed that claimed name
+}
+
+// Option configures optional behaviour of a Generator.
+type Option func(*Generator)
+
+// WithBlocklist rejects every candidate name matched by list and derives a
+// replacement instead (see GenerateExplained).
+func WithBlocklist(list *blocklist.List) Option {
+	return func(g *Generator) {
+		g.blocked = list
+	}
+}
+
+// WithDictionary only accepts names whose acronym is a word of dict, e.g.
+// a list of the team's preferred project names. Candidates are drawn so
+// that their initials spell one of the words, rather than by chance.
+func WithDictionary(dict *words.Dictionary) Option {
+	return func(g *Generator) {
+		g.dict = dict
+	}
+}
+
+// WithExclude rejects every candidate that is in names, e.g. names
+// already assigned to other features, and derives a replacement instead.
+func WithExclude(names *blocklist.Excludes) Option {
+	return func(g *Generator) {
+		g.excluded = names
+	}
+}
+
+// WithLanguages supplies the packs that language-qualified pattern keys
+// such as "de:adjectives" draw from, keyed by the tag used in the pattern.
+// Names mixing languages are composed by the rules of the generator's own
+// language.
+func WithLanguages(packs map[string]words.WordSet) Option {
+	return func(g *Generator) {
+		g.langs = packs
+	}
+}
+
+// WithRegistry makes the generator honour claims: a seed with a claim
+// always yields its claimed name, and a name claimed by one seed is never
+// issued for another.
+func WithRegistry(r Registry) Option {
+	return func(g *Generator) {
+		g.registry = r
+	}
 }
 
 type ExplainedPart struct {
 	Category string // Word category (e.g., "adjectives", "core", "suffix")
 	Word     string // The selected word from the category
-	Hash     uint64 // Raw hash value computed from the seed
-	Index    uint64 // Array index after modulo operation (Hash % ListSize)
+	Hash     uint64 // Raw value drawn from the name's keyed stream
+	Index    uint64 // Array index after modulo operation (Hash % ListSize), 0 if Locked
 	ListSize int    // Total number of words available in this category
+
+	Origin      words.Origin // Pack (and schema version) that supplied the word
+	FallbackFor string       // Requested language when the word was drawn from a fallback language (see words.Load), else empty
+
+	Letter string // Backronym letter the word spells (-backronym), else empty
+	Cycled string // Category the pattern cycle chose for Letter, if it had no word with that initial and Category stood in
+
+	Locked bool // Word was pinned by the caller (see Pick) rather than drawn
 }
 
 type ExplainedResult struct {
-	Name    string          // The final generated feature name
-	Seed    string          // The seed used for generation (auto or user-provided)
-	Pattern []string        // The word category pattern used (e.g., ["adjectives", "core", "suffix"])
-	Parts   []ExplainedPart // Detailed breakdown of each word selection
+	Name     string          // The final generated feature name
+	Acronym  string          // Initials of the name's words (see grammar.Acronym), if an acronym flag is set
+	Initial  string          // Letter -sequence made the name start with ("" without)
+	Seed     string          // The seed used for generation (auto or user-provided)
+	Pattern  []string        // The word category pattern used (e.g., ["adjectives", "core", "suffix"])
+	Parts    []ExplainedPart // Detailed breakdown of each word selection
+	Rejected []Rejection     // Candidates discarded before Name, in the order they were derived
+	Claimed  bool            // Name is the registry's claim for Seed
+}
+
+// Rejection is a candidate name that was discarded and why.
+type Rejection struct {
+	Name   string // The rejected candidate
+	Cause  Cause  // Which check rejected it
+	Reason string // What rejected it, e.g. `blocked by word "Azure" (trademarks.txt:12)`
 }
 
 // New creates a new Generator instance with the given word set and configuration.
 // The generator is ready to produce names immediately after creation.
-func New(words words.WordSet, cfg cli.Config) *Generator {
-	return &Generator{words: words, cfg: cfg}
+func New(words words.WordSet, cfg cli.Config, opts ...Option) *Generator {
+	// An explicit pattern takes precedence over the mode's default
+	pattern := cfg.Pattern
+	if len(pattern) == 0 {
+		pattern = Pattern(Mode(cfg.Mode))
+	}
+
+	// The pack's own language decides the word order, so a custom -pack
+	// composes correctly whatever -lang says
+	lang := words.Meta.Language
+	if lang == "" {
+		lang = cfg.Lang
+	}
+
+	g := &Generator{
+		words:    words,
+		cfg:      cfg,
+		pattern:  pattern,
+		date:     time.Now().Format("2006-01-02"),
+		composer: grammar.For(lang, grammar.Join(cfg.Join)),
+		casing:   grammar.Case(cfg.Case),
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	if cfg.Backronym != "" {
+		g.back = g.newBackronym(cfg.Backronym)
+	}
+	g.fixed, g.fixErr = g.newFixed(cfg.Fix)
+	g.cons = g.newConstraints(lang)
+	g.key = g.streamKey()
+	return g
+}
+
+// streamKey returns what keys the stream of every name besides its seed:
+// the pattern, followed by a backronym's letters or the -sequence number.
+// The sequence number is part of the key so that successive releases
+// differ in every word, not only in their first letter.
+func (g *Generator) streamKey() []string {
+	switch {
+	case g.back != nil:
+		return g.back.key
+	case g.cons != nil && g.cons.sequence > 0:
+		return append(slices.Clip(g.pattern), "sequence", strconv.Itoa(g.cons.sequence))
+	default:
+		return g.pattern
+	}
 }
 
 // Generate produces a single feature name for the given index.
@@ -40,8 +222,9 @@ func New(words words.WordSet, cfg cli.Config) *Generator {
 //
 // The index parameter differentiates names when generating multiple names
 // in a single run (used with -count flag).
-func (g *Generator) Generate(index int) string {
-	return g.GenerateExplained(index).Name
+func (g *Generator) Generate(index int) (string, error) {
+	result, err := g.GenerateExplained(index)
+	return result.Name, err
 }
 
 // GenerateExplained produces a feature name with full generation details.
@@ -49,69 +232,306 @@ func (g *Generator) Generate(index int) string {
 // how each word was selected.
 //
 // The generation process:
-//  1. Determine the word pattern based on the configured mode
+//  1. Determine the word pattern (explicit pattern or the configured mode's)
 //  2. Construct the seed (use provided seed or generate automatic one)
-//  3. For each word category in the pattern:
-//     a. Compute a unique hash using the seed, position, and category
-//     b. Use the hash to select a word from the category's word list
-//  4. Join all selected words with spaces to form the final name
-func (g *Generator) GenerateExplained(index int) ExplainedResult {
-	// Get the word pattern for the current mode (e.g., ["adjectives", "core", "suffix"])
-	pattern := Pattern(Mode(g.cfg.Mode))
-
-	// Determine the seed to use for hash generation
-	baseSeed := g.cfg.Seed
+//  3. Key a single stream with the seed and the pattern (see streamKey)
+//  4. For each word category in the pattern:
+//     a. Draw the next value from the stream
+//     b. Use the value to select a word from the category's word list
+//  5. Compose the selected words into the candidate name (joined with
+//     spaces, or reordered for languages such as French)
+//  6. If the candidate is rejected (e.g. by the blocklist or a
+//     constraint such as -max-length), repeat from step 4 with the
+//     next values of the same stream
+//  7. Write the accepted name in the configured case (-case)
+//
+// Because replacements come from the same stream, a seed always maps to
+// the same name for a given word set and blocklist. Candidate k at
+// position i uses value k·len(pattern)+i, so the first candidate is the
+// name the seed produces without any filtering. After MaxAttempts
+// rejected candidates an error wrapping ErrNoCandidate is returned.
+//
+// Constraints that no name of the word set can meet are detected before
+// any candidate is drawn and reported as an error wrapping
+// ErrUnsatisfiable.
+func (g *Generator) GenerateExplained(index int) (ExplainedResult, error) {
+	// Determine the seed to use for the stream
+	baseSeed, key := g.cfg.Seed, g.key
 	if baseSeed == "" {
 		// No user seed provided: generate automatic seed from config + date
-		// Format: "{lang}-{mode}-{index}-{date}"
-		// This makes names reproducible within the same day
-		baseSeed = fmt.Sprintf(
-			"%s-%s-%d-%s",
-			g.cfg.Lang,
-			g.cfg.Mode,
-			index,
-			time.Now().Format("2006-01-02"),
-		)
+		baseSeed = g.autoSeed(index)
+	} else if len(g.fixed) > 0 && index > 0 {
+		// With -fix, -count explores the free positions of the seed's
+		// name: names after the first add their index to the key, as the
+		// automatic seed does
+		key = append(slices.Clip(key), "index", strconv.Itoa(index))
+	}
+
+	return g.explain(baseSeed, key, nil)
+}
+
+// Pick derives the name of seed like GenerateExplained does with -seed,
+// except that the pattern positions in locks keep the given words, as do
+// those pinned by -fix. The other positions draw the same values as
+// without locks, so locking a word the seed already chose leaves the name
+// unchanged, and a new seed re-rolls only the free positions. Locked parts
+// are marked in the result. Backronyms ignore locks.
+func (g *Generator) Pick(seed string, locks map[int]string) (ExplainedResult, error) {
+	return g.explain(seed, g.key, locks)
+}
+
+// explain derives the name for a fully resolved seed, keying its stream
+// with key, with the pattern positions in locks and in -fix pinned to
+// their words.
+func (g *Generator) explain(baseSeed string, key []string, locks map[int]string) (ExplainedResult, error) {
+	if g.fixErr != nil {
+		return ExplainedResult{}, g.fixErr
+	}
+	if len(g.fixed) > 0 {
+		merged := maps.Clone(g.fixed)
+		maps.Copy(merged, locks)
+		locks = merged
 	}
 
-	var parts []ExplainedPart
-	var nameParts []string
+	// Get the word pattern (e.g., ["adjectives", "core", "suffix"])
+	pattern := g.pattern
+
+	// One stream per name: every position draws from the same keyed state,
+	// so the seed and pattern are hashed once rather than once per word
+	var stream Stream
+	stream.Reset(baseSeed, key)
+
+	// A claimed seed keeps its name even if the checks have changed since
+	if name, ok := g.claimedName(baseSeed); ok {
+		return g.replay(&stream, baseSeed, name), nil
+	}
+
+	// Constraints no word combination meets fail fast, for every seed,
+	// as does a backronym with a letter no word starts with
+	if g.back != nil && g.back.err != nil {
+		return ExplainedResult{}, g.back.err
+	}
+	if g.cons != nil && g.cons.err != nil {
+		return ExplainedResult{}, g.cons.err
+	}
+
+	var rejected []Rejection
+	for range MaxAttempts {
+		result, selected := g.candidate(&stream, pattern, locks)
+		result.Seed = baseSeed
+
+		// Keep the first candidate nothing objects to
+		cause, reason, ok := g.accept(result.Name, selected)
+		if ok {
+			result.Acronym = g.acronym(result.Name)
+			result.Initial = g.cons.sequenceLetter()
+			result.Name = g.casing.Apply(result.Name)
+			result.Rejected = rejected
+			return result, nil
+		}
+		g.rejections[cause].Add(1)
+		rejected = append(rejected, Rejection{Name: result.Name, Cause: cause, Reason: reason})
+	}
+
+	return ExplainedResult{}, fmt.Errorf("%w for seed %q: all %d candidates rejected (last: %s)",
+		ErrNoCandidate, baseSeed, MaxAttempts, rejected[len(rejected)-1].Reason)
+}
+
+// replay re-derives the candidates of a seed until it meets the name the
+// seed claimed, so the explanation shows how the name was drawn. If the
+// word set no longer produces it, only the name is returned.
+func (g *Generator) replay(stream *Stream, seed, name string) ExplainedResult {
+	for range MaxAttempts {
+		if result, _ := g.candidate(stream, g.pattern, nil); result.Name == name || g.casing.Apply(result.Name) == name {
+			result.Acronym = g.acronym(result.Name)
+			result.Name, result.Seed, result.Claimed = name, seed, true
+			return result
+		}
+	}
+	return ExplainedResult{Name: name, Acronym: g.acronym(name), Seed: seed, Pattern: g.pattern, Claimed: true}
+}
+
+// claimedName returns the registry's claim for seed, if any.
+func (g *Generator) claimedName(seed string) (string, bool) {
+	if g.registry == nil {
+		return "", false
+	}
+	return g.registry.ClaimedName(seed)
+}
+
+// candidate draws one value per pattern position from stream and builds
+// the name they select. It also returns the selected words in pattern
+// order, for checking the constraints.
+//
+// With constraints, the words come from the lists they allow, and an
+// alliterating candidate draws its initial before the words. Positions
+// in locks take their locked word instead of the drawn one.
+func (g *Generator) candidate(stream *Stream, pattern []string, locks map[int]string) (ExplainedResult, []grammar.Word) {
+	if g.back != nil {
+		return g.spell(stream)
+	}
+
+	parts := make([]ExplainedPart, 0, len(pattern))
+	selected := make([]grammar.Word, 0, len(pattern))
+
+	var allowed [][]string
+	if g.cons != nil {
+		allowed = g.cons.draw(stream)
+	}
 
 	// Iterate through each word category in the pattern
 	for i, key := range pattern {
-		// Get the word list for this category
-		list := g.words.Get(key)
-		if len(list) == 0 {
+		// Draw unconditionally so that a position's value does not depend
+		// on whether an earlier category happened to be empty
+		hash := stream.Next()
+
+		// Get the word list for this category, from another language's
+		// pack for a qualified key such as "de:adjectives"
+		ws, category := g.source(key)
+		list := ws.Get(category)
+		if allowed != nil {
+			list = allowed[i]
+		}
+		lock, locked := locks[i]
+		if len(list) == 0 && !locked {
 			continue // Skip empty categories
 		}
 
-		// Compute a unique hash for this word position
-		// Format: "{seed}-{position}-{category}"
-		// This ensures each position gets a different word even with the same seed
-		hash := HashToUint64(fmt.Sprintf("%s-%d-%s", baseSeed, i, key))
-
-		// Use modulo to convert the hash to a valid array index
-		idx := hash % uint64(len(list))
-
-		// Select the word at the computed index
-		word := list[idx]
+		// Use modulo to convert the value to a valid array index and
+		// select the word at it, unless the position is locked
+		var idx uint64
+		word := lock
+		if !locked {
+			idx = hash % uint64(len(list))
+			word = list[idx]
+		}
 
-		nameParts = append(nameParts, word)
+		selected = append(selected, grammar.Word{Category: category, Text: word, Features: ws.Features(word)})
 
 		// Store detailed information for explain mode
+		origin := ws.Origin(category, word)
 		parts = append(parts, ExplainedPart{
 			Category: key,
 			Word:     word,
 			Hash:     hash,
 			Index:    idx,
 			ListSize: len(list),
+			Origin:   origin,
+			Locked:   locked,
 		})
+		if ws.Lang != "" && origin.Lang != ws.Lang {
+			parts[len(parts)-1].FallbackFor = ws.Lang
+		}
 	}
 
 	return ExplainedResult{
-		Name:    strings.Join(nameParts, " "), // Combine words with spaces
-		Seed:    baseSeed,
+		Name:    g.composer.Compose(selected),
 		Pattern: pattern,
 		Parts:   parts,
+	}, selected
+}
+
+// Line formats a result for output: its name, followed by its acronym in
+// parentheses with -acronym.
+func (g *Generator) Line(result ExplainedResult) string {
+	return g.line(result.Name, result.Acronym)
+}
+
+// acronym returns the acronym of name if -acronym, -pronounceable,
+// -acronym-dict or -backronym is set, else "". Other runs skip it, as it
+// allocates for every name.
+func (g *Generator) acronym(name string) string {
+	if !g.cfg.Acronym && !g.cfg.Pronounceable && g.dict == nil && g.back == nil {
+		return ""
+	}
+	return grammar.Acronym(name)
+}
+
+// line formats a name and its acronym for output.
+func (g *Generator) line(name, acronym string) string {
+	if !g.cfg.Acronym || acronym == "" {
+		return name
+	}
+	return name + " (" + acronym + ")"
+}
+
+// source returns the word set and the plain category a pattern key draws
+// from. A key qualified with a language tag ("de:adjectives") uses that
+// language's pack (see WithLanguages); any other key uses the generator's
+// own word set. A qualified key without a pack yields an empty word set,
+// so the position is skipped like an empty category.
+func (g *Generator) source(key string) (words.WordSet, string) {
+	if lang, category, ok := strings.Cut(key, ":"); ok {
+		return g.langs[lang], category
+	}
+	return g.words, key
+}
+
+// list returns the word list a pattern key draws from.
+func (g *Generator) list(key string) []string {
+	ws, category := g.source(key)
+	return ws.Get(category)
+}
+
+// accept reports whether a candidate name may be issued, and if not, why.
+// selected are the words of the name in pattern order.
+func (g *Generator) accept(name string, selected []grammar.Word) (cause Cause, reason string, ok bool) {
+	if reason := g.cons.check(name, selected); reason != "" {
+		return Constrained, reason, false
 	}
+	if rule, blocked := g.blocked.Match(name); blocked {
+		return Blocked, "blocked by " + rule.String(), false
+	}
+	if where, used := g.excluded.Match(name); used {
+		return Excluded, "already used (" + where + ")", false
+	}
+	if g.registry != nil {
+		// Only reached for unclaimed seeds, so any claim is someone else's
+		if seed, claimed := g.registry.ClaimingSeed(name); claimed {
+			return Claimed, fmt.Sprintf("claimed by seed %q", seed), false
+		}
+	}
+	return 0, "", true
+}
+
+// acceptable reports whether a name passes accept.
+func (g *Generator) acceptable(name string, selected []grammar.Word) bool {
+	_, _, ok := g.accept(name, selected)
+	return ok
+}
+
+// SkippedLetters returns the letters of the -sequence alphabet that no
+// name can start with, upper case and in alphabet order. -sequence passes
+// over them, so release N may start with a later letter than the N-th.
+func (g *Generator) SkippedLetters() []string {
+	if g.cons == nil {
+		return nil
+	}
+	letters := make([]string, len(g.cons.skipped))
+	for i, letter := range g.cons.skipped {
+		letters[i] = strings.ToUpper(letter)
+	}
+	return letters
+}
+
+// Rejections returns how many candidates were rejected for cause by all
+// calls on this Generator so far, e.g. to report skipped names after a run.
+// Enumerate does not count towards it.
+func (g *Generator) Rejections(cause Cause) uint64 {
+	return g.rejections[cause].Load()
+}
+
+// autoSeed builds the automatic seed used when no -seed flag is given.
+// Format: "{lang}-{mode}-{index}-{date}"
+// This makes names reproducible within the same day.
+func (g *Generator) autoSeed(index int) string {
+	buf := make([]byte, 0, len(g.cfg.Lang)+len(g.cfg.Mode)+len(g.date)+24)
+	buf = append(buf, g.cfg.Lang...)
+	buf = append(buf, '-')
+	buf = append(buf, g.cfg.Mode...)
+	buf = append(buf, '-')
+	buf = strconv.AppendInt(buf, int64(index), 10)
+	buf = append(buf, '-')
+	buf = append(buf, g.date...)
+	return string(buf)
 }
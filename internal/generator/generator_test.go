@@ -44,6 +44,26 @@ func testConfig(mode, seed string) cli.Config {
 	}
 }
 
+// mustGenerate returns the name for index, failing the test on error.
+func mustGenerate(t testing.TB, g *Generator, index int) string {
+	t.Helper()
+	name, err := g.Generate(index)
+	if err != nil {
+		t.Fatalf("Generate(%d) error: %v", index, err)
+	}
+	return name
+}
+
+// mustExplain returns the explained name for index, failing the test on error.
+func mustExplain(t testing.TB, g *Generator, index int) ExplainedResult {
+	t.Helper()
+	result, err := g.GenerateExplained(index)
+	if err != nil {
+		t.Fatalf("GenerateExplained(%d) error: %v", index, err)
+	}
+	return result
+}
+
 func TestGenerate_Deterministic(t *testing.T) {
 	ws := testWordSet()
 	cfg := testConfig("startup", "test-seed")
@@ -51,8 +71,8 @@ func TestGenerate_Deterministic(t *testing.T) {
 	g1 := New(ws, cfg)
 	g2 := New(ws, cfg)
 
-	name1 := g1.Generate(0)
-	name2 := g2.Generate(0)
+	name1 := mustGenerate(t, g1, 0)
+	name2 := mustGenerate(t, g2, 0)
 
 	if name1 != name2 {
 		t.Errorf("same seed produced different names: %q vs %q", name1, name2)
@@ -65,8 +85,8 @@ func TestGenerate_DifferentSeeds(t *testing.T) {
 	g1 := New(ws, testConfig("startup", "seed-a"))
 	g2 := New(ws, testConfig("startup", "seed-b"))
 
-	name1 := g1.Generate(0)
-	name2 := g2.Generate(0)
+	name1 := mustGenerate(t, g1, 0)
+	name2 := mustGenerate(t, g2, 0)
 
 	if name1 == name2 {
 		t.Errorf("different seeds produced same name: %q", name1)
@@ -80,8 +100,8 @@ func TestGenerate_DifferentIndicesWithAutoSeed(t *testing.T) {
 	cfg := testConfig("startup", "")
 	g := New(ws, cfg)
 
-	name0 := g.Generate(0)
-	name1 := g.Generate(1)
+	name0 := mustGenerate(t, g, 0)
+	name1 := mustGenerate(t, g, 1)
 
 	if name0 == name1 {
 		t.Errorf("different indices with auto-seed produced same name: %q", name0)
@@ -93,8 +113,8 @@ func TestGenerate_CustomSeedIgnoresIndex(t *testing.T) {
 	cfg := testConfig("startup", "fixed-seed")
 	g := New(ws, cfg)
 
-	name0 := g.Generate(0)
-	name1 := g.Generate(1)
+	name0 := mustGenerate(t, g, 0)
+	name1 := mustGenerate(t, g, 1)
 
 	if name0 != name1 {
 		t.Errorf("custom seed should ignore index, got %q and %q", name0, name1)
@@ -117,7 +137,7 @@ func TestGenerate_WordCountPerMode(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.mode, func(t *testing.T) {
 			g := New(ws, testConfig(tt.mode, "count-test"))
-			result := g.GenerateExplained(0)
+			result := mustExplain(t, g, 0)
 
 			if len(result.Parts) != tt.wantWords {
 				t.Errorf("mode %q: got %d words, want %d", tt.mode, len(result.Parts), tt.wantWords)
@@ -131,7 +151,7 @@ func TestGenerateExplained_Metadata(t *testing.T) {
 	cfg := testConfig("startup", "meta-test")
 	g := New(ws, cfg)
 
-	result := g.GenerateExplained(0)
+	result := mustExplain(t, g, 0)
 
 	if result.Seed != "meta-test" {
 		t.Errorf("seed = %q, want %q", result.Seed, "meta-test")
@@ -169,7 +189,7 @@ func TestGenerate_EmptyCategory(t *testing.T) {
 	cfg := testConfig("enterprise", "empty-test")
 	g := New(ws, cfg)
 
-	result := g.GenerateExplained(0)
+	result := mustExplain(t, g, 0)
 
 	// Enterprise pattern has 4 categories, but buzzwords is empty → 3 parts
 	if len(result.Parts) != 3 {
@@ -182,7 +202,7 @@ func TestGenerate_AutoSeed(t *testing.T) {
 	cfg := testConfig("minimal", "")
 	g := New(ws, cfg)
 
-	result := g.GenerateExplained(0)
+	result := mustExplain(t, g, 0)
 
 	if result.Seed == "" {
 		t.Error("auto seed should not be empty")
@@ -197,9 +217,48 @@ func TestGenerate_UnknownModeFallsBackToMinimal(t *testing.T) {
 	cfg := testConfig("nonexistent", "fallback-test")
 	g := New(ws, cfg)
 
-	result := g.GenerateExplained(0)
+	result := mustExplain(t, g, 0)
 
 	if len(result.Parts) != 2 {
 		t.Errorf("unknown mode: got %d parts, want 2 (minimal fallback)", len(result.Parts))
 	}
 }
+
+func TestPick_Locks(t *testing.T) {
+	g := New(testWordSet(), testConfig("startup", ""))
+	for i := range 20 {
+		seed := fmt.Sprintf("pick-%d", i)
+		plain, err := g.Pick(seed, nil)
+		if err != nil {
+			t.Fatalf("Pick(%q) error: %v", seed, err)
+		}
+		if want := mustExplain(t, New(testWordSet(), testConfig("startup", seed)), 0); plain.Name != want.Name {
+			t.Errorf("Pick(%q) = %q, want the seed's name %q", seed, plain.Name, want.Name)
+		}
+
+		// Locking the seed's own word changes nothing
+		same, _ := g.Pick(seed, map[int]string{0: plain.Parts[0].Word})
+		if same.Name != plain.Name || !same.Parts[0].Locked || same.Parts[1].Locked {
+			t.Errorf("locking %q: got %q, want %q with only the first word locked", plain.Parts[0].Word, same.Name, plain.Name)
+		}
+
+		// Another locked word leaves the free positions as drawn
+		locked, _ := g.Pick(seed, map[int]string{2: "Mesh"})
+		want := plain.Parts[0].Word + " " + plain.Parts[1].Word + " Mesh"
+		if locked.Name != want {
+			t.Errorf("locking suffix Mesh: got %q, want %q", locked.Name, want)
+		}
+	}
+}
+
+// BenchmarkGenerate_Million generates a bulk run of one million names
+// per iteration with automatic seeds.
+func BenchmarkGenerate_Million(b *testing.B) {
+	g := New(largeWordSet(), testConfig("bullshit", ""))
+	b.ReportAllocs()
+	for b.Loop() {
+		for i := range benchmarkNames {
+			_, _ = g.Generate(i)
+		}
+	}
+}
@@ -7,8 +7,20 @@ const (
 	Startup    Mode = "startup"    // Balanced startup-style names
 	Enterprise Mode = "enterprise" // Corporate-sounding names with buzzwords
 	Bullshit   Mode = "bullshit"   // Over-the-top buzzword-heavy names
+
+	// Codename modes: short, memorable release names rather than product names
+	Codename  Mode = "codename"  // Adjective and animal, Ubuntu style
+	Scientist Mode = "scientist" // Adjective and scientist, Docker style
+	Cosmic    Mode = "cosmic"    // Colour and celestial object
+	Landmark  Mode = "landmark"  // Adjective and place
 )
 
+// Modes returns all built-in modes: the product name modes from the
+// simplest to the most verbose, then the codename modes.
+func Modes() []Mode {
+	return []Mode{Minimal, Startup, Enterprise, Bullshit, Codename, Scientist, Cosmic, Landmark}
+}
+
 // Pattern returns the ordered list of word categories for a given mode.
 // Each category corresponds to a key in the WordSet struct and determines
 // which word pool is used for that position in the generated name.
@@ -19,12 +31,19 @@ const (
 //   - "core":       Central concept words (Workflow, Data, Integration, ...)
 //   - "suffix":     Ending words (Hub, Engine, Platform, ...)
 //
+// The codename modes draw from their own categories: "animals", "colors",
+// "celestial", "scientists" and "places" (see words.Categories).
+//
 // Example patterns:
 //
 //	Minimal:    ["adjectives", "core"]                              → "Scalable Core"
 //	Startup:    ["adjectives", "core", "suffix"]                    → "Dynamic Workflow Hub"
 //	Enterprise: ["adjectives", "buzzwords", "core", "suffix"]       → "Unified Cloud Integration Platform"
 //	Bullshit:   ["adjectives", "buzzwords", "buzzwords", ...suffix] → "Synergized AI-Powered Blockchain Data Engine"
+//	Codename:   ["adjectives", "animals"]                           → "Jolly Jellyfish"
+//	Scientist:  ["adjectives", "scientists"]                        → "Clever Turing"
+//	Cosmic:     ["colors", "celestial"]                             → "Crimson Comet"
+//	Landmark:   ["adjectives", "places"]                            → "Quiet Fjord"
 func Pattern(mode Mode) []string {
 	switch mode {
 	case Minimal:
@@ -39,6 +58,18 @@ func Pattern(mode Mode) []string {
 	case Bullshit:
 		// Five words: double buzzwords for maximum buzzword density
 		return []string{"adjectives", "buzzwords", "buzzwords", "core", "suffix"}
+	case Codename:
+		// Two words: an adjective and an animal, like "Jammy Jellyfish"
+		return []string{"adjectives", "animals"}
+	case Scientist:
+		// Two words: an adjective and a scientist's surname
+		return []string{"adjectives", "scientists"}
+	case Cosmic:
+		// Two words: a colour and a celestial object
+		return []string{"colors", "celestial"}
+	case Landmark:
+		// Two words: an adjective and a kind of place
+		return []string{"adjectives", "places"}
 	default:
 		// Fallback to minimal for unknown modes
 		return []string{"adjectives", "core"}
@@ -1,6 +1,13 @@
 package generator
 
-import "testing"
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"fn-gen/internal/words"
+)
 
 func TestPattern_Lengths(t *testing.T) {
 	tests := []struct {
@@ -11,6 +18,10 @@ func TestPattern_Lengths(t *testing.T) {
 		{Startup, 3},
 		{Enterprise, 4},
 		{Bullshit, 5},
+		{Codename, 2},
+		{Scientist, 2},
+		{Cosmic, 2},
+		{Landmark, 2},
 	}
 
 	for _, tt := range tests {
@@ -52,3 +63,208 @@ func TestPattern_UnknownModeFallback(t *testing.T) {
 		t.Errorf("unknown mode: got %d elements, want 2 (minimal fallback)", len(p))
 	}
 }
+
+func TestModes_PatternsUseKnownCategories(t *testing.T) {
+	for _, m := range Modes() {
+		for _, key := range Pattern(m) {
+			if !slices.Contains(words.Categories(), key) {
+				t.Errorf("Pattern(%q) uses unknown category %q", m, key)
+			}
+		}
+	}
+}
+
+// bundledLangs are the languages shipped in words.DataDir. Tests that
+// load them switch to the project root, where words.DataDir resolves.
+var bundledLangs = []string{"en", "de", "fr", "es", "nl", "it"}
+
+func TestModes_BundledPacksCoverPatterns(t *testing.T) {
+	t.Chdir(filepath.Join("..", ".."))
+
+	for _, lang := range bundledLangs {
+		for _, mode := range Modes() {
+			t.Run(lang+"/"+string(mode), func(t *testing.T) {
+				ws, err := words.Load(lang, string(mode), Pattern(mode)...)
+				if err != nil {
+					t.Fatalf("Load error: %v", err)
+				}
+				for _, key := range Pattern(mode) {
+					if len(ws.Get(key)) == 0 {
+						t.Errorf("category %q of the %s pattern is empty", key, mode)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestModes_RomanceWordOrder(t *testing.T) {
+	t.Chdir(filepath.Join("..", ".."))
+
+	for _, lang := range []string{"fr", "es", "it"} {
+		ws, err := words.Load(lang, "startup")
+		if err != nil {
+			t.Fatalf("Load(%s) error: %v", lang, err)
+		}
+		g := New(ws, testConfig("startup", ""))
+		for i := range 20 {
+			result := mustExplain(t, g, i)
+
+			// The suffix is the head noun and leads; the adjective trails
+			adjective, suffix := result.Parts[0].Word, result.Parts[2].Word
+			if !strings.HasPrefix(result.Name, suffix+" ") || !strings.HasSuffix(result.Name, " "+adjective) {
+				t.Errorf("%s: %q should start with %q and end with %q", lang, result.Name, suffix, adjective)
+			}
+		}
+	}
+}
+
+func TestModes_GermanAgreement(t *testing.T) {
+	t.Chdir(filepath.Join("..", ".."))
+
+	endings := map[string]string{"m": "er", "f": "e", "n": "es"}
+	for _, mode := range []string{"minimal", "startup", "enterprise", "bullshit", "codename", "scientist", "cosmic", "landmark"} {
+		ws, err := words.Load("de", mode)
+		if err != nil {
+			t.Fatalf("Load(de/%s) error: %v", mode, err)
+		}
+		g := New(ws, testConfig(mode, ""))
+		for i := range 50 {
+			result := mustExplain(t, g, i)
+
+			// The leading adjective agrees with the last noun
+			head := result.Parts[len(result.Parts)-1].Word
+			adjective := result.Parts[0].Word
+			gender := ws.Features(head).Gender
+			if gender == "" {
+				t.Errorf("de/%s: head noun %q has no gender", mode, head)
+				continue
+			}
+			if ws.Features(adjective).Invariable {
+				continue
+			}
+			first, _, _ := strings.Cut(result.Name, " ")
+			stem := strings.TrimSuffix(adjective, "el") // Flexibel → Flexible
+			if !strings.HasPrefix(first, stem) || !strings.HasSuffix(first, endings[gender]) {
+				t.Errorf("de/%s: %q should inflect %q for %s %q", mode, result.Name, adjective, gender, head)
+			}
+		}
+	}
+}
+
+func TestModes_DutchAgreement(t *testing.T) {
+	t.Chdir(filepath.Join("..", ".."))
+
+	for _, mode := range []string{"minimal", "startup", "enterprise", "bullshit"} {
+		ws, err := words.Load("nl", mode)
+		if err != nil {
+			t.Fatalf("Load(nl/%s) error: %v", mode, err)
+		}
+		g := New(ws, testConfig(mode, ""))
+		for i := range 100 {
+			result := mustExplain(t, g, i)
+
+			// Before a het-word, the leading adjective drops its -e
+			head := result.Parts[len(result.Parts)-1].Word
+			adjective := result.Parts[0].Word
+			if ws.Features(head).Gender != "n" || ws.Features(adjective).Invariable {
+				continue
+			}
+			first, _, _ := strings.Cut(result.Name, " ")
+			if strings.HasSuffix(first, "e") {
+				t.Errorf("nl/%s: %q should take the bare form of %q before het-word %q", mode, result.Name, adjective, head)
+			}
+		}
+	}
+}
+
+func TestModes_GermanCompound(t *testing.T) {
+	t.Chdir(filepath.Join("..", ".."))
+
+	ws, err := words.Load("de", "startup")
+	if err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+	cfg := testConfig("startup", "")
+	cfg.Join = "compound"
+	spaced, compound := New(ws, testConfig("startup", "")), New(ws, cfg)
+	for i := range 20 {
+		s, c := mustExplain(t, spaced, i), mustExplain(t, compound, i)
+
+		// Same words, but core and suffix form one word
+		if len(strings.Fields(c.Name)) != len(strings.Fields(s.Name))-1 {
+			t.Errorf("compound %q should have one word less than %q", c.Name, s.Name)
+		}
+		core := c.Parts[1].Word
+		if !strings.Contains(c.Name, core) {
+			t.Errorf("compound %q should contain the core %q", c.Name, core)
+		}
+	}
+}
+
+func TestModes_FallbackLanguageIsExplained(t *testing.T) {
+	t.Chdir(filepath.Join("..", ".."))
+
+	// No de-CH packs are bundled, so every word comes from de
+	ws, err := words.Load("de-CH", "startup")
+	if err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+	for _, p := range mustExplain(t, New(ws, testConfig("startup", "x")), 0).Parts {
+		if p.Origin.Lang != "de" || p.FallbackFor != "de-CH" {
+			t.Errorf("%s %q: drawn from %q as fallback for %q, want de for de-CH", p.Category, p.Word, p.Origin.Lang, p.FallbackFor)
+		}
+	}
+
+	ws, err = words.Load("de", "startup")
+	if err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+	for _, p := range mustExplain(t, New(ws, testConfig("startup", "x")), 0).Parts {
+		if p.FallbackFor != "" {
+			t.Errorf("%s %q marked as fallback for %q", p.Category, p.Word, p.FallbackFor)
+		}
+	}
+}
+
+func TestModes_MixedLanguages(t *testing.T) {
+	t.Chdir(filepath.Join("..", ".."))
+
+	en, err := words.Load("en", "startup")
+	if err != nil {
+		t.Fatalf("Load(en) error: %v", err)
+	}
+	de, err := words.Load("de", "startup")
+	if err != nil {
+		t.Fatalf("Load(de) error: %v", err)
+	}
+
+	cfg := testConfig("startup", "mixed")
+	cfg.Pattern = []string{"de:adjectives", "core", "suffix"}
+	g := New(en, cfg, WithLanguages(map[string]words.WordSet{"de": de}))
+	result := mustExplain(t, g, 0)
+
+	want := []struct{ category, lang string }{{"de:adjectives", "de"}, {"core", "en"}, {"suffix", "en"}}
+	for i, p := range result.Parts {
+		if p.Category != want[i].category || p.Origin.Lang != want[i].lang {
+			t.Errorf("part %d: %s from %s, want %s from %s", i, p.Category, p.Origin.Lang, want[i].category, want[i].lang)
+		}
+	}
+	if !slices.Contains(de.Adjectives, result.Parts[0].Word) {
+		t.Errorf("%q is not a German adjective", result.Parts[0].Word)
+	}
+
+	// The qualified key is part of the stream key, so "en:core" draws
+	// different values than "core" even though the words are the same
+	plain := mustExplain(t, New(en, testConfig("startup", "mixed")), 0)
+	cfg.Pattern = []string{"adjectives", "en:core", "suffix"}
+	qualified := mustExplain(t, New(en, cfg, WithLanguages(map[string]words.WordSet{"en": en})), 0)
+	if plain.Parts[1].Hash == qualified.Parts[1].Hash {
+		t.Errorf("qualified and plain keys drew the same value %d", plain.Parts[1].Hash)
+	}
+
+	// Reproducible
+	if again := mustExplain(t, New(en, cfg, WithLanguages(map[string]words.WordSet{"en": en})), 0); again.Name != qualified.Name {
+		t.Errorf("same seed and pattern gave %q and %q", qualified.Name, again.Name)
+	}
+}
@@ -2,6 +2,7 @@ package generator
 
 import (
 	"crypto/sha256"
+	"crypto/sha3"
 	"encoding/binary"
 )
 
@@ -27,3 +28,50 @@ func HashToUint64(input string) uint64 {
 	// Extract first 8 bytes as uint64 (big-endian byte order)
 	return binary.BigEndian.Uint64(hash[:8])
 }
+
+// Stream is a keyed, deterministic source of 64-bit values.
+// It absorbs the seed and the word pattern once into a SHAKE256 instance
+// and then squeezes as many values as the name needs, so a name costs a
+// single hash setup instead of one SHA-256 digest per word position.
+//
+// The zero value is not keyed; use NewStream.
+type Stream struct {
+	shake sha3.SHAKE // SHAKE256 extendable-output function (zero value is ready to use)
+	buf   [8]byte    // Scratch space for one squeezed value
+}
+
+// NewStream creates a stream keyed by the seed and the ordered pattern.
+// The pattern is part of the key so that the same seed yields unrelated
+// values for different category layouts.
+//
+// Key layout (every field is terminated by a zero byte):
+//
+//	seed 0x00 category₀ 0x00 category₁ 0x00 ...
+func NewStream(seed string, pattern []string) *Stream {
+	s := &Stream{}
+	s.Reset(seed, pattern)
+	return s
+}
+
+// Reset re-keys the stream in place, allowing a Stream to be reused
+// across names without further allocations.
+func (s *Stream) Reset(seed string, pattern []string) {
+	s.shake.Reset()
+	s.absorb(seed)
+	for _, key := range pattern {
+		s.absorb(key)
+	}
+}
+
+// Next squeezes the next 8 bytes of output and returns them as a
+// big-endian uint64, matching the byte order used by HashToUint64.
+func (s *Stream) Next() uint64 {
+	_, _ = s.shake.Read(s.buf[:])
+	return binary.BigEndian.Uint64(s.buf[:])
+}
+
+// absorb writes one zero-terminated field into the SHAKE state.
+func (s *Stream) absorb(field string) {
+	_, _ = s.shake.Write([]byte(field))
+	_, _ = s.shake.Write([]byte{0})
+}
@@ -1,6 +1,11 @@
 package generator
 
-import "testing"
+import (
+	"fmt"
+	"testing"
+
+	"fn-gen/internal/cli"
+)
 
 func TestHashToUint64_Deterministic(t *testing.T) {
 	a := HashToUint64("hello")
@@ -38,3 +43,103 @@ func TestHashToUint64_EmptyString(t *testing.T) {
 		t.Errorf("empty string produced different hashes: %d vs %d", a, b)
 	}
 }
+
+func TestStream_Deterministic(t *testing.T) {
+	pattern := []string{"adjectives", "core", "suffix"}
+	a := NewStream("seed", pattern)
+	b := NewStream("seed", pattern)
+
+	for i := range 8 {
+		if x, y := a.Next(), b.Next(); x != y {
+			t.Fatalf("value %d differs: %d vs %d", i, x, y)
+		}
+	}
+}
+
+func TestStream_ValuesDifferAcrossPositions(t *testing.T) {
+	s := NewStream("seed", []string{"adjectives", "core"})
+
+	if a, b := s.Next(), s.Next(); a == b {
+		t.Errorf("consecutive values are equal: %d", a)
+	}
+}
+
+func TestStream_PatternIsPartOfKey(t *testing.T) {
+	a := NewStream("seed", []string{"adjectives", "core"})
+	b := NewStream("seed", []string{"adjectives", "buzzwords"})
+
+	if a.Next() == b.Next() {
+		t.Error("different patterns produced the same first value")
+	}
+}
+
+func TestStream_FieldsAreSeparated(t *testing.T) {
+	// Without terminators "ab"+"c" and "a"+"bc" would absorb identical bytes
+	a := NewStream("ab", []string{"c"})
+	b := NewStream("a", []string{"bc"})
+
+	if a.Next() == b.Next() {
+		t.Error("ambiguous key layouts produced the same value")
+	}
+}
+
+func TestStream_ResetMatchesNew(t *testing.T) {
+	pattern := []string{"core"}
+	fresh := NewStream("seed", pattern)
+
+	reused := NewStream("other", pattern)
+	reused.Next()
+	reused.Reset("seed", pattern)
+
+	if a, b := fresh.Next(), reused.Next(); a != b {
+		t.Errorf("reset stream = %d, want %d", b, a)
+	}
+}
+
+func TestStream_KnownValue(t *testing.T) {
+	got := NewStream("test", []string{"adjectives"}).Next()
+	want := uint64(190119879419086384)
+
+	if got != want {
+		t.Errorf("first value = %d, want %d", got, want)
+	}
+}
+
+// benchmarkNames is the size of one bulk run in the derivation benchmarks.
+const benchmarkNames = 1_000_000
+
+// BenchmarkDerive_PerWordSHA256 measures the previous derivation scheme:
+// one formatted string and one SHA-256 digest per word position.
+func BenchmarkDerive_PerWordSHA256(b *testing.B) {
+	pattern := Pattern(Bullshit)
+	b.ReportAllocs()
+	for b.Loop() {
+		var sink uint64
+		for n := range benchmarkNames {
+			seed := fmt.Sprintf("en-bullshit-%d-2026-01-15", n)
+			for i, key := range pattern {
+				sink ^= HashToUint64(fmt.Sprintf("%s-%d-%s", seed, i, key))
+			}
+		}
+		_ = sink
+	}
+}
+
+// BenchmarkDerive_Stream measures the keyed stream: one SHAKE256 setup per
+// name, reused across names, with every position squeezed from it.
+func BenchmarkDerive_Stream(b *testing.B) {
+	pattern := Pattern(Bullshit)
+	g := New(testWordSet(), cli.Config{Lang: "en", Mode: string(Bullshit)})
+	b.ReportAllocs()
+	for b.Loop() {
+		var sink uint64
+		var s Stream
+		for n := range benchmarkNames {
+			s.Reset(g.autoSeed(n), pattern)
+			for range pattern {
+				sink ^= s.Next()
+			}
+		}
+		_ = sink
+	}
+}
@@ -0,0 +1,141 @@
+package generator
+
+import (
+	"context"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+type CategoryStats struct {
+	Category string // Word category at this position
+	Size     int    // Number of entries in the category's list
+	Distinct int    // Number of distinct words in the list
+}
+
+type Stats struct {
+	Pattern    []string        // The word category pattern
+	Categories []CategoryStats // Per-position list sizes, in pattern order
+	Distinct   uint64          // Number of distinct names the pattern can produce
+}
+
+type Simulation struct {
+	Names      int // Number of names generated
+	Unique     int // Number of distinct names among them
+	Collisions int // Names that repeated an earlier name (Names - Unique)
+}
+
+// Stats describes the combination space of the configured pattern.
+//
+// Distinct is the product of the distinct word counts per position, so
+// duplicate entries inside a list do not inflate the number. Positions with
+// empty categories are listed with size 0 but, as during generation, do not
+// contribute to the product. A position pinned by -fix has size 1.
+func (g *Generator) Stats() (Stats, error) {
+	if g.fixErr != nil {
+		return Stats{}, g.fixErr
+	}
+	st := Stats{Pattern: g.pattern}
+
+	total := uint64(1)
+	var nonEmpty bool
+	for i, key := range g.pattern {
+		list := g.positionList(i)
+		distinct := countDistinct(list)
+		st.Categories = append(st.Categories, CategoryStats{
+			Category: key,
+			Size:     len(list),
+			Distinct: distinct,
+		})
+		if distinct == 0 {
+			continue
+		}
+
+		nonEmpty = true
+		hi, lo := bits.Mul64(total, uint64(distinct))
+		if hi != 0 {
+			return Stats{}, ErrTooManyCombinations
+		}
+		total = lo
+	}
+
+	if nonEmpty {
+		st.Distinct = total
+	}
+	return st, nil
+}
+
+// CollisionProbability returns the birthday-bound probability that at least
+// two of n uniformly drawn names out of a space of the given size are equal:
+//
+//	P = 1 - ∏_{i=0}^{n-1} (1 - i/space)
+//
+// The product is accumulated in log space to stay accurate for large n.
+func CollisionProbability(space uint64, n int) float64 {
+	if n < 2 {
+		return 0
+	}
+	if space == 0 || uint64(n) > space {
+		return 1 // Pigeonhole: more names than combinations
+	}
+
+	d := float64(space)
+	var logNoCollision float64
+	for i := 1; i < n; i++ {
+		logNoCollision += math.Log1p(-float64(i) / d)
+	}
+	return -math.Expm1(logNoCollision)
+}
+
+// ExpectedCollisions returns the expected number of names among n uniform
+// draws that repeat an earlier name, i.e. n minus the expected number of
+// distinct names space·(1 - (1 - 1/space)^n).
+func ExpectedCollisions(space uint64, n int) float64 {
+	if n < 2 || space == 0 {
+		return 0
+	}
+	d := float64(space)
+	unique := -d * math.Expm1(float64(n)*math.Log1p(-1/d))
+	return float64(n) - unique
+}
+
+// Simulate generates n names and counts how many of them repeat.
+//
+// With an empty template the automatic seeds for indices 0..n-1 are used,
+// exactly as `-count n` would produce them. Otherwise every "{n}" in the
+// template is replaced by the index, e.g. "TICKET-{n}" yields the seeds
+// TICKET-0, TICKET-1, ...
+func (g *Generator) Simulate(ctx context.Context, n int, template string) (Simulation, error) {
+	seen := make(map[string]struct{}, n)
+	for i := range n {
+		if err := ctx.Err(); err != nil {
+			return Simulation{}, err
+		}
+
+		seed := g.autoSeed(i)
+		if template != "" {
+			seed = strings.ReplaceAll(template, "{n}", strconv.Itoa(i))
+		}
+		result, err := g.explain(seed, g.key, nil)
+		if err != nil {
+			return Simulation{}, err
+		}
+		seen[result.Name] = struct{}{}
+	}
+
+	return Simulation{
+		Names:      n,
+		Unique:     len(seen),
+		Collisions: n - len(seen),
+	}, nil
+}
+
+// countDistinct returns the number of distinct strings in list.
+func countDistinct(list []string) int {
+	seen := make(map[string]struct{}, len(list))
+	for _, w := range list {
+		seen[w] = struct{}{}
+	}
+	return len(seen)
+}
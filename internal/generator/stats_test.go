@@ -0,0 +1,130 @@
+package generator
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"fn-gen/internal/cli"
+	"fn-gen/internal/words"
+)
+
+func TestStats_DistinctNames(t *testing.T) {
+	g := New(testWordSet(), testConfig("startup", ""))
+
+	st, err := g.Stats()
+	if err != nil {
+		t.Fatalf("Stats error: %v", err)
+	}
+	if st.Distinct != 27 {
+		t.Errorf("Distinct = %d, want 27", st.Distinct)
+	}
+	if len(st.Categories) != 3 {
+		t.Fatalf("got %d categories, want 3", len(st.Categories))
+	}
+	for _, c := range st.Categories {
+		if c.Size != 3 || c.Distinct != 3 {
+			t.Errorf("category %q: size=%d distinct=%d, want 3/3", c.Category, c.Size, c.Distinct)
+		}
+	}
+}
+
+func TestStats_DuplicatesAndEmptyCategories(t *testing.T) {
+	ws := words.WordSet{
+		Adjectives: []string{"Smart", "Smart", "Bold"},
+		Core:       []string{"Engine"},
+	}
+	g := New(ws, testConfig("enterprise", ""))
+
+	st, err := g.Stats()
+	if err != nil {
+		t.Fatalf("Stats error: %v", err)
+	}
+	// 2 distinct adjectives × 1 core; empty buzzwords and suffix are skipped
+	if st.Distinct != 2 {
+		t.Errorf("Distinct = %d, want 2", st.Distinct)
+	}
+	if st.Categories[0].Size != 3 || st.Categories[0].Distinct != 2 {
+		t.Errorf("adjectives: %+v, want size 3, distinct 2", st.Categories[0])
+	}
+	if st.Categories[1].Size != 0 {
+		t.Errorf("buzzwords size = %d, want 0", st.Categories[1].Size)
+	}
+}
+
+func TestStats_ExplicitPattern(t *testing.T) {
+	cfg := cli.Config{Lang: "en", Mode: "startup", Pattern: []string{"core", "core"}}
+	g := New(testWordSet(), cfg)
+
+	st, err := g.Stats()
+	if err != nil {
+		t.Fatalf("Stats error: %v", err)
+	}
+	if st.Distinct != 9 {
+		t.Errorf("Distinct = %d, want 9", st.Distinct)
+	}
+}
+
+func TestCollisionProbability(t *testing.T) {
+	tests := []struct {
+		name  string
+		space uint64
+		n     int
+		want  float64
+	}{
+		{"single name", 100, 1, 0},
+		{"two of two", 2, 2, 0.5},
+		{"pigeonhole", 10, 11, 1},
+		{"classic birthday", 365, 23, 0.5072972343239857},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CollisionProbability(tt.space, tt.n)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("CollisionProbability(%d, %d) = %v, want %v", tt.space, tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpectedCollisions(t *testing.T) {
+	// Two draws from two values repeat with probability 1/2
+	if got := ExpectedCollisions(2, 2); math.Abs(got-0.5) > 1e-9 {
+		t.Errorf("ExpectedCollisions(2, 2) = %v, want 0.5", got)
+	}
+	if got := ExpectedCollisions(1000, 1); got != 0 {
+		t.Errorf("ExpectedCollisions(1000, 1) = %v, want 0", got)
+	}
+}
+
+func TestSimulate_CountsCollisions(t *testing.T) {
+	// Minimal over a 3×3 space: 50 names must collide at least 41 times
+	g := New(testWordSet(), testConfig("minimal", ""))
+
+	sim, err := g.Simulate(context.Background(), 50, "")
+	if err != nil {
+		t.Fatalf("Simulate error: %v", err)
+	}
+	if sim.Names != 50 || sim.Unique > 9 || sim.Collisions != 50-sim.Unique {
+		t.Errorf("unexpected simulation result %+v", sim)
+	}
+}
+
+func TestSimulate_TemplateSeeds(t *testing.T) {
+	g := New(largeWordSet(), testConfig("startup", ""))
+
+	sim, err := g.Simulate(context.Background(), 3, "TICKET-{n}")
+	if err != nil {
+		t.Fatalf("Simulate error: %v", err)
+	}
+
+	// The template must produce the same names as explicit seeds
+	want := map[string]bool{}
+	for _, seed := range []string{"TICKET-0", "TICKET-1", "TICKET-2"} {
+		want[mustGenerate(t, New(largeWordSet(), testConfig("startup", seed)), 0)] = true
+	}
+	if sim.Unique != len(want) {
+		t.Errorf("Unique = %d, want %d", sim.Unique, len(want))
+	}
+}
@@ -0,0 +1,73 @@
+package grammar
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Acronym returns the initials of the words of a composed name, upper
+// case and without accents:
+//
+//	Acronym("Unified Customer Integration Platform") == "UCIP"
+//	Acronym("Plateforme d'Intégration Cloud")        == "PIC"
+//	Acronym("Cloud-Native Data Hub")                 == "CDH"
+//
+// Every space-separated word contributes its first letter or digit, so a
+// hyphenated word counts once. Lower-case linking words such as "de" or
+// "di" are skipped, and an elided one ("d'") gives way to the word it is
+// attached to.
+func Acronym(name string) string {
+	var b strings.Builder
+	for _, word := range strings.Fields(name) {
+		if i := strings.LastIndexAny(word, "'’"); i >= 0 {
+			word = word[i+1:]
+		} else if word == strings.ToLower(word) && strings.ContainsFunc(word, unicode.IsLetter) {
+			continue
+		}
+		for _, r := range word {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				b.WriteRune(unicode.ToUpper(baseLetter(unicode.ToLower(r))))
+				break
+			}
+		}
+	}
+	return b.String()
+}
+
+// onsets are the consonant pairs an English word can start with.
+var onsets = map[string]bool{
+	"BL": true, "BR": true, "CH": true, "CL": true, "CR": true, "DR": true, "FL": true, "FR": true,
+	"GL": true, "GR": true, "KL": true, "KR": true, "PH": true, "PL": true, "PR": true, "SC": true,
+	"SH": true, "SK": true, "SL": true, "SM": true, "SN": true, "SP": true, "ST": true, "SW": true,
+	"TH": true, "TR": true, "TW": true, "WH": true, "WR": true,
+}
+
+// Pronounceable reports whether an acronym can be read as a word rather
+// than spelt out letter by letter, by a rough rule of thumb: at least two
+// letters and nothing else, a vowel (Y counts), at most two consonants or
+// two vowels in a row, and only a common pair of consonants at the start.
+// "NASA" and "UCIP" are pronounceable, "CDH" and "PTAC" are not.
+func Pronounceable(acronym string) bool {
+	if len(acronym) < 2 || strings.ContainsFunc(acronym, func(r rune) bool { return r < 'A' || r > 'Z' }) {
+		return false
+	}
+	if !strings.ContainsAny(acronym, "AEIOUY") {
+		return false
+	}
+
+	consonants, vowels := 0, 0
+	for i, r := range acronym {
+		if strings.ContainsRune("AEIOUY", r) {
+			consonants, vowels = 0, vowels+1
+		} else {
+			consonants, vowels = consonants+1, 0
+		}
+		if consonants > 2 || vowels > 2 {
+			return false
+		}
+		if i == 1 && consonants == 2 && !onsets[acronym[:2]] {
+			return false
+		}
+	}
+	return true
+}
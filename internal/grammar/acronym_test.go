@@ -0,0 +1,47 @@
+package grammar
+
+import "testing"
+
+func TestAcronym(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Unified Customer Integration Platform", "UCIP"},
+		{"Cloud-Native Data Hub", "CDH"},
+		{"AI Data Hub", "ADH"},
+		{"Hub de Workflow Agile", "HWA"},
+		{"Plateforme d'Intégration Cloud", "PIC"},
+		{"Ökosystem Landschaftsarchitektur", "OL"},
+		{"3D Engine", "3E"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := Acronym(tt.name); got != tt.want {
+			t.Errorf("Acronym(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPronounceable(t *testing.T) {
+	tests := []struct {
+		acronym string
+		want    bool
+	}{
+		{"NASA", true},
+		{"UCIP", true},
+		{"HERMES", true},
+		{"SPAM", true},
+		{"CDH", false},  // No vowel
+		{"PTAC", false}, // PT cannot start a word
+		{"STRAP", false},
+		{"AEIO", false},
+		{"A", false},
+		{"3D", false},
+	}
+	for _, tt := range tests {
+		if got := Pronounceable(tt.acronym); got != tt.want {
+			t.Errorf("Pronounceable(%q) = %v, want %v", tt.acronym, got, tt.want)
+		}
+	}
+}
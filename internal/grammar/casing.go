@@ -0,0 +1,64 @@
+package grammar
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Case selects how a composed name is written for output, e.g. as a
+// resource identifier.
+type Case string
+
+const (
+	CaseTitle  Case = "title"  // As composed, words as the pack lists them: "Smart Data-Hub" (also the zero value)
+	CaseLower  Case = "lower"  // Lower case, spacing kept: "smart data-hub"
+	CaseUpper  Case = "upper"  // Upper case, spacing kept: "SMART DATA-HUB"
+	CaseKebab  Case = "kebab"  // Lower-case words joined by hyphens: "smart-data-hub"
+	CaseSnake  Case = "snake"  // Lower-case words joined by underscores: "smart_data_hub"
+	CaseCamel  Case = "camel"  // Words run together, all but the first capitalised: "smartDataHub"
+	CasePascal Case = "pascal" // Words run together, every one capitalised: "SmartDataHub"
+)
+
+// Cases returns the valid Case values.
+func Cases() []Case {
+	return []Case{CaseTitle, CaseLower, CaseUpper, CaseKebab, CaseSnake, CaseCamel, CasePascal}
+}
+
+// Apply writes name in case c. Kebab, snake, camel and pascal case split
+// the name into words at every character that is not a letter or digit,
+// so "AI-Assisted Hub" has three words; letters keep their accents.
+// Camel and pascal case only change the first letter of each word after
+// the first, so acronyms stay recognisable ("SmartAIHub").
+func (c Case) Apply(name string) string {
+	switch c {
+	case CaseLower:
+		return strings.ToLower(name)
+	case CaseUpper:
+		return strings.ToUpper(name)
+	case CaseKebab:
+		return strings.Join(nameWords(strings.ToLower(name)), "-")
+	case CaseSnake:
+		return strings.Join(nameWords(strings.ToLower(name)), "_")
+	case CaseCamel, CasePascal:
+		var b strings.Builder
+		for i, w := range nameWords(name) {
+			if i == 0 && c == CaseCamel {
+				b.WriteString(strings.ToLower(w))
+				continue
+			}
+			r, size := utf8.DecodeRuneInString(w)
+			b.WriteRune(unicode.ToUpper(r))
+			b.WriteString(w[size:])
+		}
+		return b.String()
+	default:
+		return name
+	}
+}
+
+// nameWords splits a name at every run of characters that are neither
+// letters nor digits.
+func nameWords(name string) []string {
+	return strings.FieldsFunc(name, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+}
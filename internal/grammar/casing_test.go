@@ -0,0 +1,28 @@
+package grammar
+
+import "testing"
+
+func TestCase_Apply(t *testing.T) {
+	tests := []struct {
+		c    Case
+		name string
+		want string
+	}{
+		{CaseTitle, "Smart AI-Assisted Hub", "Smart AI-Assisted Hub"},
+		{"", "Smart AI-Assisted Hub", "Smart AI-Assisted Hub"},
+		{CaseLower, "Smart AI-Assisted Hub", "smart ai-assisted hub"},
+		{CaseUpper, "Smart AI-Assisted Hub", "SMART AI-ASSISTED HUB"},
+		{CaseKebab, "Smart AI-Assisted Hub", "smart-ai-assisted-hub"},
+		{CaseSnake, "Smart  AI-Assisted Hub!", "smart_ai_assisted_hub"},
+		{CaseCamel, "Smart AI-Assisted Hub", "smartAIAssistedHub"},
+		{CasePascal, "smart data hub", "SmartDataHub"},
+		// Accents are kept
+		{CaseKebab, "Flux de Données Agile", "flux-de-données-agile"},
+		{CaseCamel, "Ökosystem Plattform", "ökosystemPlattform"},
+	}
+	for _, tt := range tests {
+		if got := tt.c.Apply(tt.name); got != tt.want {
+			t.Errorf("%q.Apply(%q) = %q, want %q", tt.c, tt.name, got, tt.want)
+		}
+	}
+}
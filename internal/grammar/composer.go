@@ -0,0 +1,207 @@
+package grammar
+
+import (
+	"strings"
+	"unicode"
+
+	"fn-gen/internal/words"
+)
+
+// Word is a word selected for one position of a pattern.
+type Word struct {
+	Category string         // Category it was drawn from (e.g. "core")
+	Text     string         // The word as listed in the pack
+	Features words.Features // Grammatical metadata from the pack (zero if none)
+}
+
+// Composer turns the words selected for a pattern, in pattern order, into
+// a name. Implementations must be safe for concurrent use.
+type Composer interface {
+	Compose(words []Word) string
+
+	// Order returns the order in which the words of a selection with the
+	// given categories, in pattern order, appear in the composed name, as
+	// indices into categories; the first is the word that starts the name
+	Order(categories []string) []int
+}
+
+// nounCategories are the categories whose words are nouns (see
+// words.NounCategories); all others (adjectives, buzzwords, colors)
+// modify a noun.
+var nounCategories = func() map[string]bool {
+	nouns := make(map[string]bool)
+	for _, c := range words.NounCategories() {
+		nouns[c] = true
+	}
+	return nouns
+}()
+
+// Join selects how a language that forms compound nouns writes the core
+// and suffix nouns of a name.
+type Join string
+
+const (
+	JoinSpaced   Join = "spaced"   // Separate words: "Daten Pipeline" (also the zero value)
+	JoinCompound Join = "compound" // One word with its linking element: "Datenpipeline"
+)
+
+// Joins returns the valid Join values.
+func Joins() []Join {
+	return []Join{JoinSpaced, JoinCompound}
+}
+
+// For returns the composer for a language tag such as "fr" or "es-MX".
+// Only the primary language subtag matters; languages without special
+// rules keep the pattern order and join words with spaces. join only
+// affects languages that form compounds, so far German.
+func For(lang string, join Join) Composer {
+	primary, _, _ := strings.Cut(strings.ToLower(lang), "-")
+	switch primary {
+	case "de":
+		return German{Compound: join == JoinCompound}
+	case "nl":
+		return Dutch{}
+	case "fr":
+		return Romance{Link: "de", Elide: true}
+	case "es":
+		return Romance{Link: "de"}
+	case "it":
+		return Romance{Link: "di"}
+	default:
+		return Spaced{}
+	}
+}
+
+// Spaced keeps the pattern order and joins words with single spaces, as
+// in English, where modifiers precede the noun:
+//
+//	Smart Workflow Hub
+type Spaced struct{}
+
+// Compose implements Composer.
+func (Spaced) Compose(words []Word) string {
+	var name strings.Builder
+	for _, w := range words {
+		if name.Len() > 0 {
+			name.WriteByte(' ')
+		}
+		name.WriteString(w.Text)
+	}
+	return name.String()
+}
+
+// Order implements Composer: the pattern order is kept.
+func (Spaced) Order(categories []string) []int { return patternOrder(categories) }
+
+// Romance orders words the way French, Spanish and Italian do: the last
+// noun of the pattern is the head and comes first, the other nouns follow
+// as a complement introduced by Link, and modifiers come last, buzzwords
+// before adjectives:
+//
+//	adjectives core suffix           → Hub de Workflow Agile
+//	adjectives buzzwords core suffix → Plateforme d'Intégration Cloud Stratégique
+//
+// Adjectives are not inflected, so packs for these languages list forms
+// that do not change with the noun's gender (e.g. "Agile", "Eficiente").
+type Romance struct {
+	Link  string // Preposition linking the head to its complement ("de", "di")
+	Elide bool   // Contract the preposition before a vowel ("de" + "Intégration" → "d'Intégration")
+}
+
+// Compose implements Composer.
+func (r Romance) Compose(words []Word) string {
+	var nouns, buzzwords, adjectives []string
+	for _, w := range words {
+		switch {
+		case nounCategories[w.Category]:
+			nouns = append(nouns, w.Text)
+		case w.Category == "buzzwords":
+			buzzwords = append(buzzwords, w.Text)
+		default:
+			adjectives = append(adjectives, w.Text)
+		}
+	}
+
+	// Without a noun there is nothing to reorder around
+	if len(nouns) == 0 {
+		return Spaced{}.Compose(words)
+	}
+
+	parts := []string{nouns[len(nouns)-1]}
+	if complement := nouns[:len(nouns)-1]; len(complement) > 0 {
+		parts = append(parts, r.link(complement[0]))
+		parts = append(parts, complement[1:]...)
+	}
+	parts = append(parts, buzzwords...)
+	parts = append(parts, adjectives...)
+	return strings.Join(parts, " ")
+}
+
+// Order implements Composer: the last noun, the other nouns, buzzwords,
+// then adjectives, as in Compose.
+func (Romance) Order(categories []string) []int {
+	var nouns, buzzwords, adjectives []int
+	for i, c := range categories {
+		switch {
+		case nounCategories[c]:
+			nouns = append(nouns, i)
+		case c == "buzzwords":
+			buzzwords = append(buzzwords, i)
+		default:
+			adjectives = append(adjectives, i)
+		}
+	}
+	if len(nouns) == 0 {
+		return patternOrder(categories)
+	}
+
+	order := []int{nouns[len(nouns)-1]}
+	order = append(order, nouns[:len(nouns)-1]...)
+	order = append(order, buzzwords...)
+	return append(order, adjectives...)
+}
+
+// patternOrder returns the indices of categories in pattern order.
+func patternOrder(categories []string) []int {
+	order := make([]int, len(categories))
+	for i := range order {
+		order[i] = i
+	}
+	return order
+}
+
+// link returns word preceded by the linking preposition. An elided
+// preposition is written together with the word.
+func (r Romance) link(word string) string {
+	if r.Elide && startsWithVowel(word) {
+		return r.Link[:len(r.Link)-1] + "'" + word
+	}
+	return r.Link + " " + word
+}
+
+// startsWithVowel reports whether word begins with a vowel, ignoring
+// accents (É, Î, ...).
+func startsWithVowel(word string) bool {
+	for _, r := range word {
+		return strings.ContainsRune("aeiouy", baseLetter(unicode.ToLower(r)))
+	}
+	return false
+}
+
+// baseLetter strips the accent from common accented vowels.
+func baseLetter(r rune) rune {
+	switch r {
+	case 'à', 'â', 'ä', 'á':
+		return 'a'
+	case 'é', 'è', 'ê', 'ë':
+		return 'e'
+	case 'î', 'ï', 'í':
+		return 'i'
+	case 'ô', 'ö', 'ó':
+		return 'o'
+	case 'û', 'ù', 'ü', 'ú':
+		return 'u'
+	default:
+		return r
+	}
+}
@@ -0,0 +1,76 @@
+package grammar
+
+import "testing"
+
+// selection builds a pattern's selection from category/word pairs.
+func selection(pairs ...string) []Word {
+	var ws []Word
+	for i := 0; i+1 < len(pairs); i += 2 {
+		ws = append(ws, Word{Category: pairs[i], Text: pairs[i+1]})
+	}
+	return ws
+}
+
+func TestSpaced_KeepsPatternOrder(t *testing.T) {
+	got := Spaced{}.Compose(selection("adjectives", "Smart", "core", "Workflow", "suffix", "Hub"))
+	if want := "Smart Workflow Hub"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestRomance_Compose(t *testing.T) {
+	tests := []struct {
+		lang  string
+		words []Word
+		want  string
+	}{
+		{"fr", selection("adjectives", "Agile", "core", "Workflow", "suffix", "Hub"), "Hub de Workflow Agile"},
+		{"fr", selection("adjectives", "Stratégique", "buzzwords", "Cloud", "core", "Intégration", "suffix", "Plateforme"), "Plateforme d'Intégration Cloud Stratégique"},
+		{"fr", selection("adjectives", "Simple", "core", "Module"), "Module Simple"},
+		{"es", selection("adjectives", "Ágil", "core", "Integración", "suffix", "Motor"), "Motor de Integración Ágil"},
+		{"it", selection("adjectives", "Agile", "core", "Integrazione", "suffix", "Motore"), "Motore di Integrazione Agile"},
+		{"fr-CA", selection("adjectives", "Agile", "core", "Écosystème", "suffix", "Hub"), "Hub d'Écosystème Agile"},
+		{"it", selection("adjectives", "Agile", "buzzwords", "IA", "buzzwords", "Cloud", "core", "Sistema", "suffix", "Motore"), "Motore di Sistema IA Cloud Agile"},
+		{"fr", selection("adjectives", "Agile", "buzzwords", "Cloud"), "Agile Cloud"}, // no noun: pattern order
+	}
+	for _, tt := range tests {
+		if got := For(tt.lang, "").Compose(tt.words); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.lang, got, tt.want)
+		}
+	}
+}
+
+func TestComposer_OrderMatchesCompose(t *testing.T) {
+	selections := [][]Word{
+		selection("adjectives", "Agile", "core", "Workflow", "suffix", "Hub"),
+		selection("adjectives", "Stratégique", "buzzwords", "Cloud", "core", "Intégration", "suffix", "Plateforme"),
+		selection("adjectives", "Agile", "buzzwords", "IA", "buzzwords", "Cloud", "core", "Sistema", "suffix", "Motore"),
+		selection("adjectives", "Agile", "buzzwords", "Cloud"),
+	}
+	for _, lang := range []string{"en", "fr", "it", "de"} {
+		c := For(lang, "")
+		for _, words := range selections {
+			categories := make([]string, len(words))
+			for i, w := range words {
+				categories[i] = w.Category
+			}
+
+			// The words' initials in Order must spell the composed name's acronym
+			var initials string
+			for _, i := range c.Order(categories) {
+				initials += Acronym(words[i].Text)
+			}
+			if name := c.Compose(words); Acronym(name) != initials {
+				t.Errorf("%s: %q has acronym %q, but Order gives %q", lang, name, Acronym(name), initials)
+			}
+		}
+	}
+}
+
+func TestFor_DefaultsToSpaced(t *testing.T) {
+	for _, lang := range []string{"en", "", "xx"} {
+		if _, ok := For(lang, "").(Spaced); !ok {
+			t.Errorf("For(%q) = %T, want Spaced", lang, For(lang, ""))
+		}
+	}
+}
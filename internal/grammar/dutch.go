@@ -0,0 +1,87 @@
+package grammar
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Dutch keeps the pattern order, like Spaced. Packs list adjectives in
+// their inflected form, ending in -e, which they take before de-words and
+// plurals. Names carry no article, so before a singular het-word, a noun
+// marked "gender": "n" in the pack's grammar section, adjectives take the
+// bare form; de-words are marked "m" or "f":
+//
+//	Compacte + Module (de)      → Compacte Module
+//	Compacte + Systeem (het)    → Compact Systeem
+//	Schaalbare + Platform (het) → Schaalbaar Platform
+//
+// Adjectives are the words German inflects (see isAdjective). The "n"
+// form listed in the pack's grammar section wins over the rules of
+// dutchBare; invariable words are left alone.
+type Dutch struct{}
+
+// Compose implements Composer.
+func (Dutch) Compose(words []Word) string {
+	agreement := ""
+	for _, w := range words {
+		if nounCategories[w.Category] {
+			agreement = agreementOf(w)
+		}
+	}
+
+	parts := make([]string, 0, len(words))
+	for _, w := range words {
+		text := w.Text
+		if agreement == "n" && isAdjective(w) {
+			text = dutchBare(w)
+		}
+		parts = append(parts, text)
+	}
+	return strings.Join(parts, " ")
+}
+
+// Order implements Composer: the pattern order is kept.
+func (Dutch) Order(categories []string) []int { return patternOrder(categories) }
+
+// dutchBare returns the uninflected form of adjective w. The rules undo
+// the spelling changes of the -e ending: a doubled consonant is single
+// again (Slimme → Slim), z and v become s and f (Naadloze → Naadloos,
+// Adaptieve → Adaptief), and a, o or u before the last consonant, long in
+// the open syllable, is written double (Schaalbare → Schaalbaar). A long
+// e cannot be told from the unstressed one (Incrementele, Flexibele), so
+// it is left single unless the pack lists the "n" form.
+func dutchBare(w Word) string {
+	if form, ok := w.Features.Forms["n"]; ok {
+		return form
+	}
+	if w.Features.Invariable || !strings.HasSuffix(w.Text, "e") {
+		return w.Text
+	}
+
+	stem := []rune(strings.TrimSuffix(w.Text, "e"))
+	n := len(stem)
+	if n < 2 {
+		return w.Text
+	}
+	last, prev := unicode.ToLower(stem[n-1]), unicode.ToLower(stem[n-2])
+	if last == prev && !isVowel(last) {
+		return string(stem[:n-1])
+	}
+
+	switch last {
+	case 'z':
+		stem[n-1] = 's'
+	case 'v':
+		stem[n-1] = 'f'
+	}
+	if n >= 3 && !isVowel(last) && strings.ContainsRune("aou", prev) && !isVowel(stem[n-3]) {
+		stem = slices.Insert(stem, n-1, stem[n-2])
+	}
+	return string(stem)
+}
+
+// isVowel reports whether r is a vowel, ignoring case and accents.
+func isVowel(r rune) bool {
+	return strings.ContainsRune("aeiouy", baseLetter(unicode.ToLower(r)))
+}
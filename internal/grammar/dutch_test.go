@@ -0,0 +1,53 @@
+package grammar
+
+import (
+	"testing"
+
+	"fn-gen/internal/words"
+)
+
+func TestDutch_Agreement(t *testing.T) {
+	het := words.Features{Gender: "n"}
+	plural := words.Features{Gender: "n", Number: "pl"}
+
+	tests := []struct {
+		words []Word
+		want  string
+	}{
+		// De-words keep the listed form, het-words take the bare one
+		{[]Word{{Category: "adjectives", Text: "Compacte"}, noun("core", "Module", words.Features{})}, "Compacte Module"},
+		{[]Word{{Category: "adjectives", Text: "Compacte"}, noun("core", "Systeem", het)}, "Compact Systeem"},
+		{[]Word{{Category: "adjectives", Text: "Compacte"}, noun("core", "Systemen", plural)}, "Compacte Systemen"},
+		// The head is the last noun of the pattern
+		{[]Word{{Category: "adjectives", Text: "Slimme"}, noun("core", "Platform", het), noun("suffix", "Laag", words.Features{})}, "Slimme Platform Laag"},
+		{[]Word{{Category: "adjectives", Text: "Slimme"}, noun("core", "Dienst", words.Features{}), noun("suffix", "Platform", het)}, "Slim Dienst Platform"},
+		// Spelling of the bare form
+		{[]Word{{Category: "adjectives", Text: "Schaalbare"}, noun("core", "Platform", het)}, "Schaalbaar Platform"},
+		{[]Word{{Category: "adjectives", Text: "Naadloze"}, noun("core", "Platform", het)}, "Naadloos Platform"},
+		{[]Word{{Category: "adjectives", Text: "Adaptieve"}, noun("core", "Platform", het)}, "Adaptief Platform"},
+		{[]Word{{Category: "adjectives", Text: "Autonome"}, noun("core", "Platform", het)}, "Autonoom Platform"},
+		{[]Word{{Category: "adjectives", Text: "Flexibele"}, noun("core", "Platform", het)}, "Flexibel Platform"},
+		{[]Word{{Category: "adjectives", Text: "Modulaire"}, noun("core", "Platform", het)}, "Modulair Platform"},
+		{[]Word{{Category: "adjectives", Text: "Efficiënte"}, noun("core", "Platform", het)}, "Efficiënt Platform"},
+		{[]Word{{Category: "adjectives", Text: "Verbonden"}, noun("core", "Platform", het)}, "Verbonden Platform"},
+		// Listed forms, invariable words and buzzwords
+		{[]Word{{Category: "adjectives", Text: "Operationele", Features: words.Features{Forms: map[string]string{"n": "Operationeel"}}}, noun("core", "Platform", het)}, "Operationeel Platform"},
+		{[]Word{{Category: "adjectives", Text: "Cloud-Native", Features: words.Features{Invariable: true}}, noun("core", "Platform", het)}, "Cloud-Native Platform"},
+		{[]Word{{Category: "buzzwords", Text: "Digitale", Features: words.Features{POS: "adj"}}, noun("core", "Platform", het)}, "Digitaal Platform"},
+		{[]Word{{Category: "buzzwords", Text: "Edge"}, noun("core", "Platform", het)}, "Edge Platform"},
+		{[]Word{{Category: "colors", Text: "Groene"}, noun("animals", "Paard", het)}, "Groen Paard"},
+	}
+	for _, tt := range tests {
+		if got := (Dutch{}).Compose(tt.words); got != tt.want {
+			t.Errorf("got %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestFor_Dutch(t *testing.T) {
+	for _, lang := range []string{"nl", "nl-BE", "NL"} {
+		if _, ok := For(lang, "").(Dutch); !ok {
+			t.Errorf("For(%q) = %T, want Dutch", lang, For(lang, ""))
+		}
+	}
+}
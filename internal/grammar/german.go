@@ -0,0 +1,151 @@
+package grammar
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// German keeps the pattern order, like Spaced, and inflects adjectives to
+// agree with the head noun, the last noun of the pattern. Names carry no
+// article, so adjectives take the strong nominative endings:
+//
+//	Dynamisch + Workflow (m) → Dynamischer Workflow
+//	Dynamisch + Pipeline (f) → Dynamische Pipeline
+//	Dynamisch + System (n)   → Dynamisches System
+//	Dynamisch + Daten (pl)   → Dynamische Daten
+//
+// Words of the adjectives and colors categories are inflected, as are
+// words of other categories marked "pos": "adj" in the pack's grammar
+// section. Forms listed there win over the rules; invariable words are
+// left alone. When the head noun has no gender, adjectives keep the form
+// listed in the pack.
+//
+// With Compound set, a core noun directly followed by the suffix noun is
+// written as one word, with the core's linking element from the pack's
+// grammar section (see compound):
+//
+//	Integration + Plattform → Integrationsplattform
+//	Schnittstelle + Modul   → Schnittstellenmodul
+type German struct {
+	Compound bool // Join core and suffix into a compound noun
+}
+
+// germanEndings are the strong nominative adjective endings by agreement.
+var germanEndings = map[string]string{
+	"m":  "er",
+	"f":  "e",
+	"n":  "es",
+	"pl": "e",
+}
+
+// Compose implements Composer.
+func (g German) Compose(words []Word) string {
+	agreement := ""
+	for _, w := range words {
+		if nounCategories[w.Category] {
+			agreement = agreementOf(w)
+		}
+	}
+
+	parts := make([]string, 0, len(words))
+	for i, w := range words {
+		text := w.Text
+		if agreement != "" && isAdjective(w) {
+			text = inflectGerman(w, agreement)
+		}
+
+		// The suffix joins the core right before it
+		if g.Compound && i > 0 && w.Category == "suffix" && words[i-1].Category == "core" {
+			parts[len(parts)-1] = compound(words[i-1], text)
+			continue
+		}
+		parts = append(parts, text)
+	}
+	return strings.Join(parts, " ")
+}
+
+// compound joins the noun first and the word second into one word. The
+// linking element of first ("s", "n", "en", ...) goes in between and
+// second loses its capital: Integration + Plattform → Integrationsplattform.
+// Acronyms and words that already contain a hyphen or space are joined
+// with a hyphen instead (API-Plattform), as is any first word whose
+// linking element is "-".
+func compound(first Word, second string) string {
+	link := first.Features.Link
+	if link == "-" || needsHyphen(first.Text) || needsHyphen(second) {
+		return first.Text + "-" + second
+	}
+	return first.Text + link + lowerFirst(second)
+}
+
+// needsHyphen reports whether word cannot be merged into a compound
+// without a hyphen: an acronym such as "API" or "KI", or a word that
+// contains a hyphen or space.
+func needsHyphen(word string) bool {
+	if strings.ContainsAny(word, "- ") {
+		return true
+	}
+	letters := 0
+	for _, r := range word {
+		if unicode.IsLower(r) {
+			return false
+		}
+		if unicode.IsLetter(r) {
+			letters++
+		}
+	}
+	return letters > 1
+}
+
+// lowerFirst lower-cases the first letter of word.
+func lowerFirst(word string) string {
+	for i, r := range word {
+		return string(unicode.ToLower(r)) + word[i+utf8.RuneLen(r):]
+	}
+	return word
+}
+
+// Order implements Composer: the pattern order is kept.
+func (German) Order(categories []string) []int { return patternOrder(categories) }
+
+// agreementOf returns the form key an adjective agreeing with noun takes:
+// "pl" for plural nouns, else the noun's gender ("" if unknown).
+func agreementOf(noun Word) string {
+	if noun.Features.Number == "pl" {
+		return "pl"
+	}
+	return noun.Features.Gender
+}
+
+// isAdjective reports whether w is inflected like an adjective.
+func isAdjective(w Word) bool {
+	if w.Features.POS != "" {
+		return w.Features.POS == "adj"
+	}
+	return w.Category == "adjectives" || w.Category == "colors"
+}
+
+// inflectGerman returns the form of adjective w for the given agreement.
+// The rules cover the regular cases; the unstressed e of -el and of -er
+// after a diphthong is dropped (Flexibel → Flexible, Teuer → Teure), and a
+// stem ending in e takes the ending without its e (Leise → Leiser).
+func inflectGerman(w Word, agreement string) string {
+	if form, ok := w.Features.Forms[agreement]; ok {
+		return form
+	}
+	if w.Features.Invariable {
+		return w.Text
+	}
+
+	stem, ending := w.Text, germanEndings[agreement]
+	lower := strings.ToLower(stem)
+	switch {
+	case strings.HasSuffix(lower, "e"):
+		ending = strings.TrimPrefix(ending, "e")
+	case strings.HasSuffix(lower, "el"),
+		strings.HasSuffix(lower, "auer"), strings.HasSuffix(lower, "euer"):
+		stem = stem[:len(stem)-2] + stem[len(stem)-1:]
+	}
+	return stem + ending
+}
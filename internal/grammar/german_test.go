@@ -0,0 +1,96 @@
+package grammar
+
+import (
+	"testing"
+
+	"fn-gen/internal/words"
+)
+
+// noun returns a noun selected from category with the given features.
+func noun(category, text string, f words.Features) Word {
+	return Word{Category: category, Text: text, Features: f}
+}
+
+func TestGerman_Agreement(t *testing.T) {
+	masc := words.Features{Gender: "m"}
+	fem := words.Features{Gender: "f"}
+	neut := words.Features{Gender: "n"}
+	plural := words.Features{Number: "pl"}
+
+	tests := []struct {
+		words []Word
+		want  string
+	}{
+		{[]Word{{Category: "adjectives", Text: "Dynamisch"}, noun("core", "Workflow", masc)}, "Dynamischer Workflow"},
+		{[]Word{{Category: "adjectives", Text: "Dynamisch"}, noun("core", "Daten", plural), noun("suffix", "Pipeline", fem)}, "Dynamische Daten Pipeline"},
+		{[]Word{{Category: "adjectives", Text: "Dynamisch"}, noun("core", "System", neut)}, "Dynamisches System"},
+		{[]Word{{Category: "adjectives", Text: "Dynamisch"}, noun("core", "Daten", plural)}, "Dynamische Daten"},
+		// -el drops its e, as does -er after a diphthong; a final e is not doubled
+		{[]Word{{Category: "adjectives", Text: "Flexibel"}, noun("core", "Stack", masc)}, "Flexibler Stack"},
+		{[]Word{{Category: "adjectives", Text: "Teuer"}, noun("core", "Modul", neut)}, "Teures Modul"},
+		{[]Word{{Category: "adjectives", Text: "Leise"}, noun("core", "Prozess", masc)}, "Leiser Prozess"},
+		{[]Word{{Category: "adjectives", Text: "Sicher"}, noun("core", "Service", masc)}, "Sicherer Service"},
+		// Buzzwords are inflected only when marked as adjectives
+		{[]Word{{Category: "buzzwords", Text: "Digital", Features: words.Features{POS: "adj"}}, noun("core", "Plattform", fem)}, "Digitale Plattform"},
+		{[]Word{{Category: "buzzwords", Text: "Cloud"}, noun("core", "Plattform", fem)}, "Cloud Plattform"},
+		// Colours agree like adjectives, with codename nouns as the head
+		{[]Word{{Category: "colors", Text: "Golden"}, noun("celestial", "Galaxie", fem)}, "Goldene Galaxie"},
+		{[]Word{{Category: "colors", Text: "Lila", Features: words.Features{Invariable: true}}, noun("celestial", "Mond", masc)}, "Lila Mond"},
+		{[]Word{{Category: "adjectives", Text: "Mutig"}, noun("animals", "Zebra", neut)}, "Mutiges Zebra"},
+		// Listed forms and invariable words override the rules
+		{[]Word{{Category: "adjectives", Text: "Next-Gen", Features: words.Features{Invariable: true}}, noun("core", "Engine", fem)}, "Next-Gen Engine"},
+		{[]Word{{Category: "adjectives", Text: "Rosa", Features: words.Features{Forms: map[string]string{"m": "Rosaner"}}}, noun("core", "Hub", masc)}, "Rosaner Hub"},
+		// Without a known gender the listed form is kept
+		{[]Word{{Category: "adjectives", Text: "Dynamisch"}, noun("core", "Mesh", words.Features{})}, "Dynamisch Mesh"},
+		{[]Word{{Category: "adjectives", Text: "Dynamisch"}}, "Dynamisch"},
+	}
+	for _, tt := range tests {
+		if got := (German{}).Compose(tt.words); got != tt.want {
+			t.Errorf("got %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestFor_German(t *testing.T) {
+	for _, lang := range []string{"de", "de-AT", "DE"} {
+		if _, ok := For(lang, "").(German); !ok {
+			t.Errorf("For(%q) = %T, want German", lang, For(lang, ""))
+		}
+	}
+}
+
+func TestGerman_Compound(t *testing.T) {
+	fem := words.Features{Gender: "f"}
+	tests := []struct {
+		words []Word
+		want  string
+	}{
+		{[]Word{noun("core", "Daten", words.Features{Number: "pl"}), noun("suffix", "Pipeline", fem)}, "Datenpipeline"},
+		{[]Word{noun("core", "Integration", words.Features{Gender: "f", Link: "s"}), noun("suffix", "Plattform", fem)}, "Integrationsplattform"},
+		{[]Word{noun("core", "Schnittstelle", words.Features{Gender: "f", Link: "n"}), noun("suffix", "Modul", words.Features{Gender: "n"})}, "Schnittstellenmodul"},
+		// The compound takes the gender of its last part
+		{[]Word{{Category: "adjectives", Text: "Dynamisch"}, noun("core", "Workflow", words.Features{Gender: "m"}), noun("suffix", "Plattform", fem)}, "Dynamische Workflowplattform"},
+		// Acronyms, hyphenated words and a "-" link keep a hyphen
+		{[]Word{noun("core", "API", fem), noun("suffix", "Plattform", fem)}, "API-Plattform"},
+		{[]Word{noun("core", "Workflow", words.Features{}), noun("suffix", "KI", fem)}, "Workflow-KI"},
+		{[]Word{noun("core", "Cloud-Nativ", words.Features{}), noun("suffix", "Hub", fem)}, "Cloud-Nativ-Hub"},
+		{[]Word{noun("core", "Service", words.Features{Link: "-"}), noun("suffix", "Hub", fem)}, "Service-Hub"},
+		// Only a core directly followed by the suffix is joined
+		{[]Word{{Category: "buzzwords", Text: "Cloud"}, noun("core", "Ökosystem", words.Features{Gender: "n"})}, "Cloud Ökosystem"},
+		{[]Word{noun("core", "Ökosystem", words.Features{Gender: "n"}), noun("suffix", "Übersicht", fem)}, "Ökosystemübersicht"},
+	}
+	for _, tt := range tests {
+		if got := (German{Compound: true}).Compose(tt.words); got != tt.want {
+			t.Errorf("got %q, want %q", got, tt.want)
+		}
+	}
+
+	// Spaced by default
+	spaced := []Word{noun("core", "Daten", words.Features{Number: "pl"}), noun("suffix", "Pipeline", fem)}
+	if got := For("de", "").Compose(spaced); got != "Daten Pipeline" {
+		t.Errorf("default join: got %q, want %q", got, "Daten Pipeline")
+	}
+	if got := For("de", JoinCompound).Compose(spaced); got != "Datenpipeline" {
+		t.Errorf("compound join: got %q, want %q", got, "Datenpipeline")
+	}
+}
@@ -0,0 +1,83 @@
+package grammar
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Fold lower-cases s and strips the accents of common accented vowels,
+// for comparisons that should not care about either ("Écosystème" and
+// "ecosysteme" fold to the same string).
+func Fold(s string) string {
+	return strings.Map(func(r rune) rune { return baseLetter(unicode.ToLower(r)) }, s)
+}
+
+// Initial returns the alliteration key of word: its first letter or
+// digit, folded. With bySound set, spellings of the same opening sound
+// share a key and all vowels alliterate with each other, roughly as an
+// English speaker hears them:
+//
+//	Initial("Cloud", true)   == Initial("Kafka", true)  == "k"
+//	Initial("Cyber", true)   == Initial("Sync", true)   == "s"
+//	Initial("Phoenix", true) == Initial("Fast", true)   == "f"
+//	Initial("Agile", true)   == Initial("Engine", true) == "vowel"
+//
+// It returns "" for a word without letters or digits.
+func Initial(word string, bySound bool) string {
+	w := strings.TrimLeftFunc(Fold(word), func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+	if w == "" {
+		return ""
+	}
+	if !bySound {
+		for _, r := range w {
+			return string(r)
+		}
+	}
+
+	// Multi-letter spellings first, then single letters
+	for _, s := range []struct{ spelling, sound string }{
+		{"sch", "sh"}, {"sh", "sh"}, {"ch", "ch"}, {"th", "th"}, {"ph", "f"},
+		{"kn", "n"}, {"gn", "n"}, {"wr", "r"}, {"ps", "s"}, {"ce", "s"}, {"ci", "s"}, {"cy", "s"},
+	} {
+		if strings.HasPrefix(w, s.spelling) {
+			return s.sound
+		}
+	}
+	for _, r := range w {
+		switch {
+		case strings.ContainsRune("aeiou", r):
+			return "vowel"
+		case r == 'c' || r == 'q':
+			return "k"
+		case r == 'x':
+			return "z"
+		default:
+			return string(r)
+		}
+	}
+	return ""
+}
+
+// Syllables estimates the number of syllables of text by counting groups
+// of vowels in each word. In English a final silent e does not count
+// ("Engine" has two, "Scalable" three). The estimate is meant for
+// limiting name length, not for hyphenation.
+func Syllables(text, lang string) int {
+	english := strings.HasPrefix(strings.ToLower(lang), "en")
+	total := 0
+	for _, word := range strings.FieldsFunc(Fold(text), func(r rune) bool { return !unicode.IsLetter(r) }) {
+		n, inVowels := 0, false
+		for _, r := range word {
+			vowel := strings.ContainsRune("aeiouy", r)
+			if vowel && !inVowels {
+				n++
+			}
+			inVowels = vowel
+		}
+		if english && n > 1 && strings.HasSuffix(word, "e") && !strings.HasSuffix(word, "le") && !strings.HasSuffix(word, "ee") {
+			n--
+		}
+		total += max(n, 1)
+	}
+	return total
+}
@@ -0,0 +1,62 @@
+package grammar
+
+import "testing"
+
+func TestFold(t *testing.T) {
+	if got := Fold("Écosystème"); got != "ecosysteme" {
+		t.Errorf("Fold = %q, want %q", got, "ecosysteme")
+	}
+}
+
+func TestInitial(t *testing.T) {
+	tests := []struct {
+		word    string
+		bySound bool
+		want    string
+	}{
+		{"Cloud", false, "c"},
+		{"Écosystème", false, "e"},
+		{"3D-Mesh", false, "3"},
+		{"-", false, ""},
+		// By sound, spellings of one opening sound share a key
+		{"Cloud", true, "k"},
+		{"Kafka", true, "k"},
+		{"Cyber", true, "s"},
+		{"Sync", true, "s"},
+		{"Phoenix", true, "f"},
+		{"Knowledge", true, "n"},
+		{"Schema", true, "sh"},
+		{"Shard", true, "sh"},
+		{"Xenon", true, "z"},
+		{"Agile", true, "vowel"},
+		{"Über", true, "vowel"},
+	}
+	for _, tt := range tests {
+		if got := Initial(tt.word, tt.bySound); got != tt.want {
+			t.Errorf("Initial(%q, %v) = %q, want %q", tt.word, tt.bySound, got, tt.want)
+		}
+	}
+}
+
+func TestSyllables(t *testing.T) {
+	tests := []struct {
+		text string
+		lang string
+		want int
+	}{
+		{"Hub", "en", 1},
+		{"Engine", "en", 2},
+		{"Scalable", "en", 3},
+		{"Smart Data Pipeline", "en", 6},
+		{"AI-Assisted Hub", "en", 5},
+		// The silent e is English only
+		{"Engine", "de", 3},
+		{"Lösung", "de", 2},
+		{"Nth", "en", 1},
+	}
+	for _, tt := range tests {
+		if got := Syllables(tt.text, tt.lang); got != tt.want {
+			t.Errorf("Syllables(%q, %q) = %d, want %d", tt.text, tt.lang, got, tt.want)
+		}
+	}
+}
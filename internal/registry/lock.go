@@ -0,0 +1,54 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"time"
+)
+
+// lockPoll is how often a waiting process retries the lock.
+const lockPoll = 50 * time.Millisecond
+
+// acquire takes the lock file at path and returns a function that
+// releases it.
+//
+// The lock is a file created with O_EXCL, which is atomic on local file
+// systems and on network file systems that CI runners share, and needs no
+// platform-specific calls. The file records who holds the lock, so when a
+// process dies while holding it, the timeout error says which file to
+// remove. Locks are never broken automatically: a stale lock is rare, but
+// stealing a live one would allow exactly the double assignment the lock
+// exists to prevent.
+func acquire(path string, timeout time.Duration) (release func(), err error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err == nil {
+			host, _ := os.Hostname()
+			fmt.Fprintf(f, "pid %d on %s since %s\n", os.Getpid(), host, time.Now().Format(time.RFC3339))
+			f.Close()
+			return func() { os.Remove(path) }, nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, fmt.Errorf("cannot lock registry: %w", err)
+		}
+
+		if time.Now().After(deadline) {
+			holder, _ := os.ReadFile(path)
+			return nil, fmt.Errorf("cannot lock registry: %s is held by %s; remove it if that process is gone",
+				path, orUnknown(strings.TrimSpace(string(holder))))
+		}
+		time.Sleep(lockPoll)
+	}
+}
+
+// orUnknown substitutes a placeholder for an unreadable lock holder.
+func orUnknown(s string) string {
+	if s == "" {
+		return "an unknown process"
+	}
+	return s
+}
@@ -0,0 +1,236 @@
+package registry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"fn-gen/internal/blocklist"
+)
+
+// DefaultPath is the registry file used when none is given, relative to
+// the working directory.
+const DefaultPath = ".fn-gen-registry.json"
+
+// version is the format version written to new registry files.
+const version = 1
+
+// ErrNotFound is returned when a seed or name has no claim.
+var ErrNotFound = errors.New("no claim found")
+
+// Claim is a name assigned to a seed.
+type Claim struct {
+	Name      string    `json:"name"`       // The issued name
+	Seed      string    `json:"seed"`       // The seed it was issued for (e.g. a ticket ID)
+	Lang      string    `json:"lang"`       // Language it was generated in
+	Mode      string    `json:"mode"`       // Mode it was generated in
+	ClaimedAt time.Time `json:"claimed_at"` // When the claim was recorded
+}
+
+// Data is the content of a registry file. Names are indexed by
+// blocklist.Key, so "Smart Data Hub", "smart-data-hub" and "SmartDataHub"
+// are the same name.
+//
+// A Data is not safe for concurrent modification, but any number of
+// goroutines may read it (e.g. a bulk generator) while nobody modifies it.
+type Data struct {
+	Version int     `json:"version"` // Format version of the file
+	Claims  []Claim `json:"claims"`  // All claims, oldest first
+
+	bySeed map[string]int // Seed → index into Claims
+	byKey  map[string]int // Name key → index into Claims
+}
+
+// index rebuilds the lookup maps after Claims changed.
+func (d *Data) index() {
+	d.bySeed = make(map[string]int, len(d.Claims))
+	d.byKey = make(map[string]int, len(d.Claims))
+	for i, c := range d.Claims {
+		d.bySeed[c.Seed] = i
+		d.byKey[blocklist.Key(c.Name)] = i
+	}
+}
+
+// BySeed returns the claim recorded for seed.
+func (d *Data) BySeed(seed string) (Claim, bool) {
+	i, ok := d.bySeed[seed]
+	if !ok {
+		return Claim{}, false
+	}
+	return d.Claims[i], true
+}
+
+// ByName returns the claim on name, compared by blocklist.Key.
+func (d *Data) ByName(name string) (Claim, bool) {
+	i, ok := d.byKey[blocklist.Key(name)]
+	if !ok {
+		return Claim{}, false
+	}
+	return d.Claims[i], true
+}
+
+// ClaimedName returns the name claimed for seed. Together with
+// ClaimingSeed it lets a generator honour the registry.
+func (d *Data) ClaimedName(seed string) (string, bool) {
+	c, ok := d.BySeed(seed)
+	return c.Name, ok
+}
+
+// ClaimingSeed returns the seed that claimed name.
+func (d *Data) ClaimingSeed(name string) (string, bool) {
+	c, ok := d.ByName(name)
+	return c.Seed, ok
+}
+
+// Add records a claim. It fails if the seed already has a claim or the
+// name is claimed by any seed.
+func (d *Data) Add(c Claim) error {
+	if prev, ok := d.BySeed(c.Seed); ok {
+		return fmt.Errorf("seed %q already claimed %q", c.Seed, prev.Name)
+	}
+	if prev, ok := d.ByName(c.Name); ok {
+		return fmt.Errorf("name %q already claimed by seed %q", prev.Name, prev.Seed)
+	}
+	d.Claims = append(d.Claims, c)
+	d.index()
+	return nil
+}
+
+// remove deletes the claim at index i and returns it.
+func (d *Data) remove(i int) Claim {
+	c := d.Claims[i]
+	d.Claims = slices.Delete(d.Claims, i, i+1)
+	d.index()
+	return c
+}
+
+// ReleaseSeed removes the claim of seed and returns it.
+func (d *Data) ReleaseSeed(seed string) (Claim, error) {
+	i, ok := d.bySeed[seed]
+	if !ok {
+		return Claim{}, fmt.Errorf("seed %q: %w", seed, ErrNotFound)
+	}
+	return d.remove(i), nil
+}
+
+// ReleaseName removes the claim on name and returns it.
+func (d *Data) ReleaseName(name string) (Claim, error) {
+	i, ok := d.byKey[blocklist.Key(name)]
+	if !ok {
+		return Claim{}, fmt.Errorf("name %q: %w", name, ErrNotFound)
+	}
+	return d.remove(i), nil
+}
+
+// Store is a registry file on disk. Every access goes through a lock file
+// next to it, so concurrent processes (e.g. CI jobs on a shared volume)
+// see each other's claims and never assign a name twice.
+type Store struct {
+	path        string        // Registry file
+	LockTimeout time.Duration // How long to wait for the lock (default 30s)
+}
+
+// Open returns the store at path. The file is created by the first Update;
+// until then the registry is empty.
+func Open(path string) *Store {
+	return &Store{path: path, LockTimeout: 30 * time.Second}
+}
+
+// Path returns the registry file.
+func (s *Store) Path() string { return s.path }
+
+// View calls fn with the current contents while holding the lock.
+func (s *Store) View(fn func(*Data) error) error {
+	unlock, err := acquire(s.path+".lock", s.LockTimeout)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	data, err := s.read()
+	if err != nil {
+		return err
+	}
+	return fn(data)
+}
+
+// Update calls fn with the current contents while holding the lock and
+// writes them back if fn returns nil. The file is replaced atomically, so
+// a crash never leaves a half-written registry behind.
+func (s *Store) Update(fn func(*Data) error) error {
+	unlock, err := acquire(s.path+".lock", s.LockTimeout)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	data, err := s.read()
+	if err != nil {
+		return err
+	}
+	if err := fn(data); err != nil {
+		return err
+	}
+	return s.write(data)
+}
+
+// read loads the registry file; a missing file is an empty registry.
+func (s *Store) read() (*Data, error) {
+	data := &Data{Version: version}
+	raw, err := os.ReadFile(s.path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		data.index()
+		return data, nil
+	case err != nil:
+		return nil, fmt.Errorf("cannot read registry: %w", err)
+	}
+
+	if err := json.Unmarshal(raw, data); err != nil {
+		return nil, fmt.Errorf("cannot read registry: %s: %w", s.path, err)
+	}
+	if data.Version > version {
+		return nil, fmt.Errorf("cannot read registry: %s: version %d is newer than supported version %d", s.path, data.Version, version)
+	}
+	data.index()
+	return data, nil
+}
+
+// write stores data in a temporary file next to the registry and renames
+// it over the old one.
+func (s *Store) write(data *Data) error {
+	if data.Claims == nil {
+		data.Claims = []Claim{} // Keep "claims": [] rather than null
+	}
+	raw, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	raw = append(raw, '\n')
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("cannot write registry: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // No-op after a successful rename
+
+	_, err = tmp.Write(raw)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), s.path)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot write registry: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func testStore(t *testing.T) *Store {
+	t.Helper()
+	return Open(filepath.Join(t.TempDir(), "registry.json"))
+}
+
+func TestData_AddAndLookup(t *testing.T) {
+	var d Data
+	d.index()
+
+	if err := d.Add(Claim{Name: "Smart Data Hub", Seed: "JIRA-1"}); err != nil {
+		t.Fatalf("Add error: %v", err)
+	}
+
+	if c, ok := d.BySeed("JIRA-1"); !ok || c.Name != "Smart Data Hub" {
+		t.Errorf("BySeed = %v, %v", c, ok)
+	}
+	if c, ok := d.ByName("smart-data-hub"); !ok || c.Seed != "JIRA-1" {
+		t.Errorf("ByName by slug = %v, %v", c, ok)
+	}
+	if c, ok := d.ByName("SmartDataHub"); !ok || c.Seed != "JIRA-1" {
+		t.Errorf("ByName in pascal case = %v, %v", c, ok)
+	}
+
+	// Neither the seed nor the name may be claimed twice
+	if err := d.Add(Claim{Name: "Fast Engine", Seed: "JIRA-1"}); err == nil {
+		t.Error("expected error for a claimed seed")
+	}
+	if err := d.Add(Claim{Name: "SMART data hub", Seed: "JIRA-2"}); err == nil {
+		t.Error("expected error for a claimed name")
+	}
+}
+
+func TestData_Release(t *testing.T) {
+	var d Data
+	d.index()
+	d.Add(Claim{Name: "Smart Data Hub", Seed: "JIRA-1"})
+	d.Add(Claim{Name: "Fast Engine", Seed: "JIRA-2"})
+
+	if c, err := d.ReleaseName("smart data hub"); err != nil || c.Seed != "JIRA-1" {
+		t.Errorf("ReleaseName = %v, %v", c, err)
+	}
+	if c, err := d.ReleaseSeed("JIRA-2"); err != nil || c.Name != "Fast Engine" {
+		t.Errorf("ReleaseSeed = %v, %v", c, err)
+	}
+	if _, err := d.ReleaseSeed("JIRA-2"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("second release error = %v, want ErrNotFound", err)
+	}
+
+	// A released name can be claimed again
+	if err := d.Add(Claim{Name: "Smart Data Hub", Seed: "JIRA-3"}); err != nil {
+		t.Errorf("re-claim error: %v", err)
+	}
+}
+
+func TestStore_Persists(t *testing.T) {
+	s := testStore(t)
+	claimed := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	err := s.Update(func(d *Data) error {
+		return d.Add(Claim{Name: "Smart Data Hub", Seed: "JIRA-1", Lang: "en", Mode: "startup", ClaimedAt: claimed})
+	})
+	if err != nil {
+		t.Fatalf("Update error: %v", err)
+	}
+
+	err = Open(s.Path()).View(func(d *Data) error {
+		c, ok := d.BySeed("JIRA-1")
+		if !ok || c.Name != "Smart Data Hub" || !c.ClaimedAt.Equal(claimed) || d.Version != version {
+			t.Errorf("reloaded claim = %+v (version %d)", c, d.Version)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("View error: %v", err)
+	}
+
+	// The lock is released afterwards
+	if _, err := os.Stat(s.Path() + ".lock"); !os.IsNotExist(err) {
+		t.Errorf("lock file left behind: %v", err)
+	}
+}
+
+func TestStore_FailedUpdateWritesNothing(t *testing.T) {
+	s := testStore(t)
+	err := s.Update(func(d *Data) error {
+		d.Add(Claim{Name: "Smart Data Hub", Seed: "JIRA-1"})
+		return errors.New("abort")
+	})
+	if err == nil {
+		t.Fatal("expected the callback's error")
+	}
+	if _, err := os.Stat(s.Path()); !os.IsNotExist(err) {
+		t.Errorf("registry written despite error: %v", err)
+	}
+}
+
+func TestStore_EmptyAndCorrupt(t *testing.T) {
+	s := testStore(t)
+	if err := s.View(func(d *Data) error {
+		if len(d.Claims) != 0 {
+			t.Errorf("missing file should be empty, got %v", d.Claims)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("View error: %v", err)
+	}
+
+	os.WriteFile(s.Path(), []byte("{not json"), 0o644)
+	if err := s.View(func(*Data) error { return nil }); err == nil {
+		t.Error("expected error for a corrupt registry")
+	}
+
+	os.WriteFile(s.Path(), []byte(`{"version": 99, "claims": []}`), 0o644)
+	if err := s.View(func(*Data) error { return nil }); err == nil || !strings.Contains(err.Error(), "newer") {
+		t.Errorf("expected version error, got %v", err)
+	}
+}
+
+func TestStore_ConcurrentClaims(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "registry.json")
+
+	// Every worker tries to claim the same names for its own seeds, with
+	// its own Store as separate processes would
+	const workers, names = 8, 20
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	won := make(map[string]int)
+	for w := range workers {
+		wg.Go(func() {
+			s := Open(path)
+			for n := range names {
+				err := s.Update(func(d *Data) error {
+					return d.Add(Claim{Name: fmt.Sprintf("Name %d", n), Seed: fmt.Sprintf("W%d-%d", w, n)})
+				})
+				if err == nil {
+					mu.Lock()
+					won[fmt.Sprintf("Name %d", n)]++
+					mu.Unlock()
+				}
+			}
+		})
+	}
+	wg.Wait()
+
+	for n := range names {
+		if got := won[fmt.Sprintf("Name %d", n)]; got != 1 {
+			t.Errorf("Name %d claimed %d times", n, got)
+		}
+	}
+	Open(path).View(func(d *Data) error {
+		if len(d.Claims) != names {
+			t.Errorf("registry holds %d claims, want %d", len(d.Claims), names)
+		}
+		return nil
+	})
+}
+
+func TestStore_LockTimeout(t *testing.T) {
+	s := testStore(t)
+	s.LockTimeout = 100 * time.Millisecond
+	os.WriteFile(s.Path()+".lock", []byte("pid 42 on ci-runner since 2026-01-15T10:00:00Z\n"), 0o644)
+
+	err := s.Update(func(*Data) error { return nil })
+	if err == nil || !strings.Contains(err.Error(), "pid 42 on ci-runner") {
+		t.Errorf("expected lock holder in error, got %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+package words
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// Dictionary is a list of real words, e.g. for acronyms that should spell
+// one (-acronym-dict). A nil *Dictionary holds no words.
+type Dictionary struct {
+	source string   // File the words came from
+	words  []string // Words in file order, each listed once (ignoring case)
+}
+
+// ParseDictionary reads words from r, one per line. Blank lines and lines
+// starting with '#' are ignored, as are entries that are not a single
+// word of letters ("e.g.", "rock'n'roll"), since no acronym can spell
+// them. source names the input in error messages.
+func ParseDictionary(source string, r io.Reader) (*Dictionary, error) {
+	d := &Dictionary{source: source}
+	seen := make(map[string]bool)
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		word := strings.TrimSpace(sc.Text())
+		if word == "" || strings.HasPrefix(word, "#") || strings.ContainsFunc(word, func(r rune) bool { return !unicode.IsLetter(r) }) {
+			continue
+		}
+		if key := strings.ToUpper(word); !seen[key] {
+			seen[key] = true
+			d.words = append(d.words, word)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+	return d, nil
+}
+
+// LoadDictionary reads the words in the file at path.
+func LoadDictionary(path string) (*Dictionary, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load dictionary: %w", err)
+	}
+	defer f.Close()
+
+	d, err := ParseDictionary(path, f)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load dictionary: %w", err)
+	}
+	return d, nil
+}
+
+// Source returns the file the words came from.
+func (d *Dictionary) Source() string {
+	if d == nil {
+		return ""
+	}
+	return d.source
+}
+
+// Words returns the words in file order. The slice must not be modified.
+func (d *Dictionary) Words() []string {
+	if d == nil {
+		return nil
+	}
+	return d.words
+}
@@ -0,0 +1,46 @@
+package words
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseDictionary(t *testing.T) {
+	input := `# Acronym targets
+hermes
+Falcon
+
+HERMES
+e.g.
+rock'n'roll
+  Atlas  
+`
+	d, err := ParseDictionary("dict.txt", strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseDictionary error: %v", err)
+	}
+	if want := []string{"hermes", "Falcon", "Atlas"}; !slices.Equal(d.Words(), want) {
+		t.Errorf("Words() = %q, want %q", d.Words(), want)
+	}
+	if d.Source() != "dict.txt" {
+		t.Errorf("Source() = %q", d.Source())
+	}
+}
+
+func TestLoadDictionary(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "words.txt", "atlas\n")
+	d, err := LoadDictionary(path)
+	if err != nil || len(d.Words()) != 1 {
+		t.Fatalf("LoadDictionary = %v, %v", d.Words(), err)
+	}
+
+	if _, err := LoadDictionary(filepath.Join(t.TempDir(), "missing.txt")); err == nil || !strings.Contains(err.Error(), "cannot load dictionary") {
+		t.Errorf("missing file: error = %v", err)
+	}
+	var none *Dictionary
+	if none.Words() != nil {
+		t.Error("nil dictionary has words")
+	}
+}
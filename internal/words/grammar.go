@@ -0,0 +1,91 @@
+package words
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// grammarKey is the top-level key of the optional grammar section in a
+// word file. The section needs schema version 2.
+const grammarKey = "grammar"
+
+// grammarSchemaVersion is the first schema version with a grammar section.
+const grammarSchemaVersion = 2
+
+// Features is the grammatical metadata of a word, listed in the grammar
+// section of a pack and keyed by the word as it appears in the categories:
+//
+//	"grammar": {
+//	  "Workflow": {"gender": "m"},
+//	  "Daten":    {"number": "pl"},
+//	  "Digital":  {"pos": "adj"},
+//	  "Flexibel": {"forms": {"m": "Flexibler", "f": "Flexible", "n": "Flexibles", "pl": "Flexible"}},
+//	  "Next-Gen": {"invariable": true},
+//	  "Integration": {"gender": "f", "link": "s"}
+//	}
+//
+// All fields are optional. Languages without agreement ignore them.
+type Features struct {
+	Gender     string            `json:"gender,omitempty"`     // Gender of a noun: "m", "f" or "n"
+	Number     string            `json:"number,omitempty"`     // Number of a noun: "sg" (default) or "pl"
+	POS        string            `json:"pos,omitempty"`        // Part of speech: "noun" or "adj" (default: from the category)
+	Forms      map[string]string `json:"forms,omitempty"`      // Adjective forms by agreement ("m", "f", "n", "pl"), overriding the language's rules
+	Invariable bool              `json:"invariable,omitempty"` // Adjective that is never inflected (e.g. "Next-Gen")
+	Link       string            `json:"link,omitempty"`       // Linking element when the noun starts a compound ("s", "n", "en", or "-" for a hyphen)
+}
+
+// Allowed values of the Features fields.
+var (
+	genders   = []string{"m", "f", "n"}
+	numbers   = []string{"sg", "pl"}
+	posValues = []string{"noun", "adj"}
+	formKeys  = []string{"m", "f", "n", "pl"}
+)
+
+// validate checks the values of the features of word.
+func (f Features) validate(word string) error {
+	switch {
+	case f.Gender != "" && !slices.Contains(genders, f.Gender):
+		return fmt.Errorf("%s %q: gender %q must be one of %v", grammarKey, word, f.Gender, genders)
+	case f.Number != "" && !slices.Contains(numbers, f.Number):
+		return fmt.Errorf("%s %q: number %q must be one of %v", grammarKey, word, f.Number, numbers)
+	case f.POS != "" && !slices.Contains(posValues, f.POS):
+		return fmt.Errorf("%s %q: pos %q must be one of %v", grammarKey, word, f.POS, posValues)
+	case f.Invariable && len(f.Forms) > 0:
+		return fmt.Errorf("%s %q: invariable words cannot list forms", grammarKey, word)
+	case f.Link != "-" && strings.ContainsFunc(f.Link, func(r rune) bool { return !unicode.IsLower(r) }):
+		return fmt.Errorf("%s %q: link %q must be lower-case letters or \"-\"", grammarKey, word, f.Link)
+	}
+	for _, key := range slices.Sorted(maps.Keys(f.Forms)) {
+		if !slices.Contains(formKeys, key) {
+			return fmt.Errorf("%s %q: unknown form %q (valid: %v)", grammarKey, word, key, formKeys)
+		}
+	}
+	return nil
+}
+
+// validateGrammar checks a pack's grammar section against its header and
+// returns the first problem, in word order.
+func validateGrammar(grammar map[string]Features, meta Meta) error {
+	if len(grammar) == 0 {
+		return nil
+	}
+	if meta.SchemaVersion < grammarSchemaVersion {
+		return fmt.Errorf("%s requires %s.schema_version %d", grammarKey, metaKey, grammarSchemaVersion)
+	}
+	for _, word := range slices.Sorted(maps.Keys(grammar)) {
+		if err := grammar[word].validate(word); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Features returns the grammatical metadata of word, or the zero value if
+// the pack has none. Entries of a pack override those it inherits.
+func (w WordSet) Features(word string) Features {
+	return w.grammar[word]
+}
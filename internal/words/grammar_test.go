@@ -0,0 +1,113 @@
+package words
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadFile_Grammar(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "base.json", `{
+  "meta": {"schema_version": 2},
+  "grammar": {
+    "Workflow": {"gender": "m"},
+    "Flexibel": {"forms": {"m": "Flexibler"}}
+  },
+  "adjectives": ["Flexibel"],
+  "core": ["Workflow"]
+}`)
+	path := writeFile(t, dir, "child.json", `{
+  "meta": {"schema_version": 2, "extends": "base.json"},
+  "grammar": {
+    "Workflow": {"gender": "n"},
+    "Daten": {"number": "pl"}
+  },
+  "core": {"add": ["Daten"]}
+}`)
+
+	ws, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile error: %v", err)
+	}
+	if got := ws.Features("Workflow").Gender; got != "n" {
+		t.Errorf("Workflow gender = %q, want the child's %q", got, "n")
+	}
+	if got := ws.Features("Daten").Number; got != "pl" {
+		t.Errorf("Daten number = %q, want %q", got, "pl")
+	}
+	if got := ws.Features("Flexibel").Forms["m"]; got != "Flexibler" {
+		t.Errorf("inherited Flexibel form = %q, want %q", got, "Flexibler")
+	}
+	if got := ws.Features("Unknown"); got.Gender != "" || got.Forms != nil {
+		t.Errorf("Features of an unlisted word = %+v, want zero", got)
+	}
+}
+
+func TestDecode_GrammarErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"old schema", `{"grammar": {"Hub": {"gender": "m"}}}`, "grammar requires meta.schema_version 2"},
+		{"bad gender", `{"meta": {"schema_version": 2}, "grammar": {"Hub": {"gender": "x"}}}`, `grammar "Hub": gender "x" must be one of`},
+		{"bad number", `{"meta": {"schema_version": 2}, "grammar": {"Hub": {"number": "du"}}}`, `number "du"`},
+		{"bad pos", `{"meta": {"schema_version": 2}, "grammar": {"Hub": {"pos": "verb"}}}`, `pos "verb"`},
+		{"bad link", `{"meta": {"schema_version": 2}, "grammar": {"Hub": {"link": "S"}}}`, `link "S" must be lower-case letters`},
+		{"bad form", `{"meta": {"schema_version": 2}, "grammar": {"Neu": {"forms": {"dat": "Neuem"}}}}`, `unknown form "dat"`},
+		{"invariable with forms", `{"meta": {"schema_version": 2}, "grammar": {"Neu": {"invariable": true, "forms": {"m": "Neuer"}}}}`, "invariable words cannot list forms"},
+		{"unknown field", `{"meta": {"schema_version": 2}, "grammar": {"Hub": {"genus": "m"}}}`, `grammar: unknown field "genus"`},
+		{"wrong type", `{"meta": {"schema_version": 2}, "grammar": {"Hub": {"gender": 1}}}`, "grammar.Hub.gender must be a string"},
+		{"twice", `{"meta": {"schema_version": 2}, "grammar": {}, "grammar": {}}`, `"grammar" defined twice`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decode("test.json", "de", "startup", []byte(tt.data), nil)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate_Grammar(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "startup.json", `{
+  "meta": {"schema_version": 2},
+  "grammar": {
+    "Workflow": {"gender": "m"},
+    "Daten": {"number": "pl"},
+    "Ghost": {"gender": "m"}
+  },
+  "adjectives": ["Smart"],
+  "core": ["Workflow", "Daten"],
+  "suffix": ["Hub"]
+}`)
+
+	issues, err := Validate(path, testPatterns)
+	if err != nil {
+		t.Fatalf("Validate error: %v", err)
+	}
+	if i, ok := findIssue(issues, `grammar entry "Ghost" matches no word`); !ok || i.Severity != Warning || i.Line != 3 {
+		t.Errorf("expected unmatched entry warning on line 3, got %v", issues)
+	}
+	if i, ok := findIssue(issues, `noun "Hub" in suffix has no gender`); !ok || i.Line != 10 {
+		t.Errorf("expected missing gender warning on line 10, got %v", issues)
+	}
+	// Plural nouns need no gender
+	if _, ok := findIssue(issues, `noun "Daten"`); ok {
+		t.Errorf("plural noun reported without gender: %v", issues)
+	}
+}
+
+func TestValidate_GrammarSchemaError(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "startup.json", `{"grammar": {"Hub": {"gender": "m"}}, "core": ["Hub"]}`)
+
+	issues, err := Validate(path, nil)
+	if err != nil {
+		t.Fatalf("Validate error: %v", err)
+	}
+	if i, ok := findIssue(issues, "grammar requires meta.schema_version 2"); !ok || i.Severity != Error {
+		t.Errorf("expected schema version error, got %v", issues)
+	}
+}
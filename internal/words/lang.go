@@ -0,0 +1,98 @@
+package words
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultLang is the language every fallback chain ends with.
+const DefaultLang = "en"
+
+// ParseTag checks a BCP 47 language tag and returns it in canonical case:
+// language in lower case, script in title case, region in upper case:
+//
+//	ParseTag("de-ch")      == "de-CH"
+//	ParseTag("zh_hant_tw") == "zh-Hant-TW"
+//
+// Underscores are accepted as separators. The supported subset is
+// language (2-3 letters), then optionally script (4 letters), region
+// (2 letters or 3 digits) and variants (5-8 letters or digits, or a digit
+// followed by 3); extensions and private use subtags are rejected.
+func ParseTag(tag string) (string, error) {
+	subtags := strings.FieldsFunc(tag, func(r rune) bool { return r == '-' || r == '_' })
+	if len(subtags) == 0 || len(subtags) != strings.Count(tag, "-")+strings.Count(tag, "_")+1 {
+		return "", fmt.Errorf("invalid language tag %q", tag)
+	}
+
+	// Subtags must appear in this order; each kind at most once except variants
+	const (
+		language = iota
+		script
+		region
+		variant
+	)
+	next := language
+	for i, s := range subtags {
+		if !isAlnum(s) {
+			return "", fmt.Errorf("invalid language tag %q: subtag %q", tag, s)
+		}
+		switch {
+		case i == 0 && isAlpha(s) && len(s) >= 2 && len(s) <= 3:
+			subtags[i] = strings.ToLower(s)
+			next = script
+		case i == 0:
+			return "", fmt.Errorf("invalid language tag %q: language %q must be 2 or 3 letters", tag, s)
+		case next <= script && len(s) == 4 && isAlpha(s):
+			subtags[i] = strings.ToUpper(s[:1]) + strings.ToLower(s[1:])
+			next = region
+		case next <= region && (len(s) == 2 && isAlpha(s) || len(s) == 3 && isDigits(s)):
+			subtags[i] = strings.ToUpper(s)
+			next = variant
+		case len(s) >= 5 && len(s) <= 8 || len(s) == 4 && isDigits(s[:1]):
+			subtags[i] = strings.ToLower(s)
+			next = variant
+		default:
+			return "", fmt.Errorf("invalid language tag %q: unexpected subtag %q", tag, s)
+		}
+	}
+	return strings.Join(subtags, "-"), nil
+}
+
+// Fallbacks returns the languages to try for a canonical tag, most
+// specific first: the tag itself, each shorter prefix, and DefaultLang.
+//
+//	Fallbacks("de-CH")      == [de-CH de en]
+//	Fallbacks("zh-Hant-TW") == [zh-Hant-TW zh-Hant zh en]
+//	Fallbacks("en-GB")      == [en-GB en]
+func Fallbacks(tag string) []string {
+	var chain []string
+	for t := tag; t != ""; {
+		chain = append(chain, t)
+		i := strings.LastIndexByte(t, '-')
+		if i < 0 {
+			break
+		}
+		t = t[:i]
+	}
+	if chain[len(chain)-1] != DefaultLang {
+		chain = append(chain, DefaultLang)
+	}
+	return chain
+}
+
+// isAlpha reports whether s consists of ASCII letters only.
+func isAlpha(s string) bool {
+	return !strings.ContainsFunc(s, func(r rune) bool { return (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') })
+}
+
+// isDigits reports whether s consists of ASCII digits only.
+func isDigits(s string) bool {
+	return !strings.ContainsFunc(s, func(r rune) bool { return r < '0' || r > '9' })
+}
+
+// isAlnum reports whether s is a non-empty run of ASCII letters and digits.
+func isAlnum(s string) bool {
+	return s != "" && !strings.ContainsFunc(s, func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9')
+	})
+}
@@ -0,0 +1,130 @@
+package words
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseTag(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"en", "en"},
+		{"DE", "de"},
+		{"de-ch", "de-CH"},
+		{"de_CH", "de-CH"},
+		{"zh-hant-tw", "zh-Hant-TW"},
+		{"es-419", "es-419"},
+		{"sl-rozaj", "sl-rozaj"},
+		{"de-CH-1996", "de-CH-1996"},
+	}
+	for _, tt := range tests {
+		got, err := ParseTag(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseTag(%q) = %q, %v; want %q", tt.in, got, err, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "e", "english", "de--CH", "de-", "de-CH-DE", "de-Latn-Latn", "de-x-foo", "dé"} {
+		if got, err := ParseTag(bad); err == nil {
+			t.Errorf("ParseTag(%q) = %q, want an error", bad, got)
+		}
+	}
+}
+
+func TestFallbacks(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want []string
+	}{
+		{"de-CH", []string{"de-CH", "de", "en"}},
+		{"zh-Hant-TW", []string{"zh-Hant-TW", "zh-Hant", "zh", "en"}},
+		{"en-GB", []string{"en-GB", "en"}},
+		{"en", []string{"en"}},
+	}
+	for _, tt := range tests {
+		if got := Fallbacks(tt.tag); !slices.Equal(got, tt.want) {
+			t.Errorf("Fallbacks(%q) = %v, want %v", tt.tag, got, tt.want)
+		}
+	}
+}
+
+// writeDataDir creates DataDir below a temporary working directory with
+// the given files, keyed by path relative to DataDir.
+func writeDataDir(t *testing.T, files map[string]string) {
+	t.Helper()
+	t.Chdir(t.TempDir())
+	for name, content := range files {
+		writeFile(t, DataDir, name, content)
+	}
+}
+
+func TestLoad_Fallback(t *testing.T) {
+	writeDataDir(t, map[string]string{
+		"en/startup.json":    `{"meta": {"name": "English"}, "adjectives": ["Smart"], "buzzwords": ["Cloud"], "core": ["Data"], "suffix": ["Hub"]}`,
+		"en/minimal.json":    `{"meta": {"name": "English Minimal"}, "adjectives": ["Simple"], "core": ["Module"]}`,
+		"de/startup.json":    `{"meta": {"name": "Deutsch"}, "adjectives": ["Schlau"], "core": ["Daten"]}`,
+		"de-CH/startup.json": `{"meta": {"name": "Schweiz"}, "core": ["Datenbestand"]}`,
+	})
+
+	// Category level: de-CH declares core only, de adds adjectives, en the rest
+	ws, err := Load("de-ch", "startup", "adjectives", "core", "suffix")
+	if err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+	tests := []struct {
+		key, word, lang string
+	}{
+		{"core", "Datenbestand", "de-CH"},
+		{"adjectives", "Schlau", "de"},
+		{"suffix", "Hub", "en"},
+		{"buzzwords", "Cloud", "en"},
+	}
+	for _, tt := range tests {
+		if got := ws.Get(tt.key); !slices.Equal(got, []string{tt.word}) {
+			t.Errorf("%s = %v, want [%s]", tt.key, got, tt.word)
+		}
+		if got := ws.Origin(tt.key, tt.word).Lang; got != tt.lang {
+			t.Errorf("%s %q drawn from %q, want %q", tt.key, tt.word, got, tt.lang)
+		}
+	}
+	if ws.Lang != "de-CH" || ws.Meta.Name != "Schweiz" {
+		t.Errorf("Lang = %q, pack = %q; want de-CH from Schweiz", ws.Lang, ws.Meta.Name)
+	}
+	if want := []string{"de/startup", "en/startup"}; !slices.Equal(ws.Fallback, want) {
+		t.Errorf("Fallback = %v, want %v", ws.Fallback, want)
+	}
+
+	// File level: neither de-CH nor de has a minimal pack
+	ws, err = Load("de-CH", "minimal", "adjectives", "core")
+	if err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+	if ws.Meta.Name != "English Minimal" || ws.Origin("core", "Module").Lang != "en" {
+		t.Errorf("got pack %q, want the English fallback", ws.Meta.Name)
+	}
+}
+
+func TestLoad_FallbackErrors(t *testing.T) {
+	writeDataDir(t, map[string]string{
+		"en/startup.json": `{"adjectives": ["Smart"], "core": ["Data"]}`,
+		"de/startup.json": `{"adjectives": ["Schlau"]}`,
+	})
+
+	tests := []struct {
+		lang, mode string
+		want       string
+	}{
+		{"xx", "startup", `no word packs for language "xx"`},
+		{"de_", "startup", "invalid language tag"},
+		{"de", "enterprise", "no enterprise pack for de (tried de, en)"},
+		{"de", "startup", "missing required categories: suffix"}, // no language has it
+	}
+	for _, tt := range tests {
+		_, err := Load(tt.lang, tt.mode, "adjectives", "core", "suffix")
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Load(%s, %s) error = %v, want it to contain %q", tt.lang, tt.mode, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package words
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DataDir is the directory the bundled word packs are loaded from,
+// relative to the project root.
+const DataDir = "internal/words/data"
+
+type PackInfo struct {
+	Lang string // Language directory (e.g. "en")
+	Mode string // Mode file name without extension (e.g. "startup")
+	Path string // Path of the word file
+	Meta Meta   // Parsed header with defaults applied
+	Err  error  // Set if the pack failed to load; Meta is then incomplete
+}
+
+// List returns every pack in DataDir in directory order (by language, then
+// mode). Packs that fail to parse are still listed with Err set, so a
+// single broken file does not hide the others.
+func List() ([]PackInfo, error) {
+	langs, err := os.ReadDir(DataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var packs []PackInfo
+	for _, l := range langs {
+		if !l.IsDir() {
+			continue
+		}
+		files, err := os.ReadDir(filepath.Join(DataDir, l.Name()))
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range files {
+			if f.IsDir() || filepath.Ext(f.Name()) != ".json" {
+				continue
+			}
+			mode := strings.TrimSuffix(f.Name(), ".json")
+			ws, err := Load(l.Name(), mode)
+			packs = append(packs, PackInfo{
+				Lang: l.Name(),
+				Mode: mode,
+				Path: filepath.Join(DataDir, l.Name(), f.Name()),
+				Meta: ws.Meta,
+				Err:  err,
+			})
+		}
+	}
+	return packs, nil
+}
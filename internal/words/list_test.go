@@ -0,0 +1,28 @@
+package words
+
+import "testing"
+
+func TestList_BundledPacks(t *testing.T) {
+	chdirToRoot(t)
+
+	packs, err := List()
+	if err != nil {
+		t.Fatalf("List error: %v", err)
+	}
+	if len(packs) < 8 {
+		t.Fatalf("got %d packs, want at least 8", len(packs))
+	}
+
+	for _, p := range packs {
+		if p.Err != nil {
+			t.Errorf("%s/%s: %v", p.Lang, p.Mode, p.Err)
+			continue
+		}
+		if p.Meta.Name == "" || p.Meta.SchemaVersion == 0 {
+			t.Errorf("%s/%s: incomplete header %+v", p.Lang, p.Mode, p.Meta)
+		}
+		if p.Meta.Language != p.Lang {
+			t.Errorf("%s/%s: language %q", p.Lang, p.Mode, p.Meta.Language)
+		}
+	}
+}
@@ -1,10 +1,13 @@
 package words
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 )
 
 type WordSet struct {
@@ -12,44 +15,180 @@ type WordSet struct {
 	Buzzwords  []string `json:"buzzwords"`  // Trendy tech terms (Cloud, AI-Assisted, Serverless, ...)
 	Core       []string `json:"core"`       // Central concept words (Workflow, Data, Integration, ...)
 	Suffix     []string `json:"suffix"`     // Ending words (Hub, Engine, Platform, ...)
+
+	// Codename categories
+	Animals    []string `json:"animals"`    // Animals (Jellyfish, Falcon, Otter, ...)
+	Colors     []string `json:"colors"`     // Colours, used like adjectives (Crimson, Teal, Amber, ...)
+	Celestial  []string `json:"celestial"`  // Celestial objects (Comet, Nebula, Pulsar, ...)
+	Scientists []string `json:"scientists"` // Surnames of scientists (Curie, Turing, Noether, ...)
+	Places     []string `json:"places"`     // Landscapes and landmarks (Fjord, Canyon, Sierra, ...)
+
+	Meta     Meta     `json:"meta"` // Pack header (name, schema version, language, ...)
+	Chain    []string `json:"-"`    // Packs that contributed, from this pack to the root of its extends chain
+	Fallback []string `json:"-"`    // Packs of fallback languages that supplied missing categories, in order
+	Lang     string   `json:"-"`    // Language tag requested from Load, canonicalised (empty for LoadFile)
+
+	origins map[originKey]Origin // Per-word provenance (nil: every word comes from Meta's pack)
+	defined map[string]bool      // Categories declared somewhere in the chain
+	grammar map[string]Features  // Grammatical metadata by word, merged along the chain
+}
+
+// originKey identifies a word within a category for provenance lookups.
+type originKey struct {
+	category string
+	word     string
 }
 
 // Load reads a word set from a JSON file based on language and mode.
 // The file path is constructed as: internal/words/data/{lang}/{mode}.json
 //
 // Parameters:
-//   - lang: Language code (e.g., "en", "de")
+//   - lang: BCP 47 language tag (e.g., "en", "de", "de-CH")
 //   - mode: Generation mode (e.g., "startup", "enterprise")
+//   - required: Categories that must be present in the file (usually the
+//     mode's pattern); an empty list is fine, a missing key is not
+//
+// Languages fall back along Fallbacks(lang), e.g. de-CH → de → en:
+//
+//  1. The pack is the first {lang}/{mode}.json of the chain that exists,
+//     so a de-CH directory only needs the modes it changes
+//  2. Categories the pack does not declare (anywhere in its extends
+//     chain) are taken from the same mode of the following languages;
+//     Origin reports the language each word was actually drawn from
+//
+// At least one language of the chain before the default must have a data
+// directory, so a mistyped -lang is an error rather than English.
+//
+// Decoding is strict: unknown categories (e.g. a typo like "adjectivs"),
+// non-string entries and trailing data after the object are rejected.
+// Syntax and schema errors carry the file's line and column.
 //
 // Returns an error if the file cannot be read or parsed.
 //
 // Example file paths:
 //   - internal/words/data/en/startup.json
 //   - internal/words/data/de/enterprise.json
-func Load(lang, mode string) (WordSet, error) {
-	// Construct the path to the word file
-	path := filepath.Join("internal", "words", "data", lang, mode+".json")
+func Load(lang, mode string, required ...string) (WordSet, error) {
+	tag, err := ParseTag(lang)
+	if err != nil {
+		return WordSet{}, fmt.Errorf("cannot load words: %w", err)
+	}
+	chain := Fallbacks(tag)
+
+	// The language itself must be known; the default it falls back to
+	// does not count
+	own := chain
+	if primary, _, _ := strings.Cut(tag, "-"); primary != DefaultLang {
+		own = chain[:len(chain)-1]
+	}
+	if !slices.ContainsFunc(own, func(l string) bool { return isDir(filepath.Join(DataDir, l)) }) {
+		return WordSet{}, fmt.Errorf("cannot load words: no word packs for language %q", tag)
+	}
+
+	var ws WordSet
+	var path string // File of the pack, for error messages
+	for _, l := range chain {
+		p := filepath.Join(DataDir, l, mode+".json")
+		data, err := os.ReadFile(p)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return WordSet{}, fmt.Errorf("cannot load words: %w", err)
+		}
+		pack, err := resolve(p, l, mode, data, nil)
+		if err != nil {
+			return WordSet{}, fmt.Errorf("cannot load words: %w", err)
+		}
+
+		if path == "" {
+			ws, path = pack, p
+		} else {
+			ws.fillFrom(pack)
+		}
+		if ws.complete() {
+			break
+		}
+	}
+	if path == "" {
+		return WordSet{}, fmt.Errorf("cannot load words: no %s pack for %s (tried %s)", mode, tag, strings.Join(chain, ", "))
+	}
+
+	if err := checkRequired(path, ws, required); err != nil {
+		return WordSet{}, err
+	}
+	ws.Lang = tag
+	return ws, nil
+}
+
+// LoadFile reads a word pack from an arbitrary path, e.g. a team's own
+// overlay kept outside the bundled data. Its language and mode are taken
+// from the path when it follows the {lang}/{mode}.json layout. The pattern
+// is still chosen with -mode or -pattern; the file only supplies words.
+func LoadFile(path string, required ...string) (WordSet, error) {
+	lang, mode := packIdentity(path)
+	return loadPath(path, lang, mode, required)
+}
 
+// loadPath reads and decodes the word file at path.
+func loadPath(path, lang, mode string, required []string) (WordSet, error) {
 	// Read the entire file content
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return WordSet{}, fmt.Errorf("cannot load words: %w", err)
 	}
 
-	// Parse JSON into WordSet struct
-	var ws WordSet
-	if err := json.Unmarshal(data, &ws); err != nil {
+	return decode(path, lang, mode, data, required)
+}
+
+// decode strictly parses the contents of a word file into a WordSet,
+// resolving its extends chain. lang and mode identify the pack for header
+// validation and defaults.
+func decode(path, lang, mode string, data []byte, required []string) (WordSet, error) {
+	ws, err := resolve(path, lang, mode, data, nil)
+	if err != nil {
+		return WordSet{}, fmt.Errorf("cannot load words: %w", err)
+	}
+	if err := checkRequired(path, ws, required); err != nil {
 		return WordSet{}, err
 	}
-
 	return ws, nil
 }
 
+// checkRequired reports every required category missing from ws at once,
+// by name. A category counts as present if any pack in the chain declares
+// it, even as an empty list.
+func checkRequired(path string, ws WordSet, required []string) error {
+	var missing []string
+	for _, key := range required {
+		if !ws.defined[key] {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("cannot load words: %s: missing required categories: %s", path, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// Categories returns the names of all word categories in a WordSet,
+// in the order they appear in the word files: the product name
+// categories, then the codename categories.
+func Categories() []string {
+	return []string{"adjectives", "buzzwords", "core", "suffix", "animals", "colors", "celestial", "scientists", "places"}
+}
+
+// NounCategories returns the categories whose words are nouns. The words
+// of the other categories (adjectives, buzzwords, colors) modify a noun.
+func NounCategories() []string {
+	return []string{"core", "suffix", "animals", "celestial", "scientists", "places"}
+}
+
 // Get retrieves the word list for a given category key.
 // This provides a dynamic way to access word pools by name,
 // which is used by the generator when iterating through patterns.
 //
-// Valid keys: "adjectives", "buzzwords", "core", "suffix"
+// Valid keys: see Categories
 // Returns nil for unknown keys.
 func (w WordSet) Get(key string) []string {
 	switch key {
@@ -61,7 +200,105 @@ func (w WordSet) Get(key string) []string {
 		return w.Core
 	case "suffix":
 		return w.Suffix
+	case "animals":
+		return w.Animals
+	case "colors":
+		return w.Colors
+	case "celestial":
+		return w.Celestial
+	case "scientists":
+		return w.Scientists
+	case "places":
+		return w.Places
 	default:
 		return nil // Unknown category
 	}
 }
+
+// Origin reports which pack supplied word in the given category,
+// for explain output and auditing.
+func (w WordSet) Origin(category, word string) Origin {
+	if o, ok := w.origins[originKey{category, word}]; ok {
+		return o
+	}
+	return Origin{
+		Pack:          w.Meta.Name,
+		SchemaVersion: w.Meta.SchemaVersion,
+		Lang:          w.Meta.Language,
+	}
+}
+
+// fillFrom takes the categories ws does not declare from fallback, the
+// pack of the next language in the fallback chain. Their words keep the
+// origin they have in fallback, and their grammar entries come along.
+func (w *WordSet) fillFrom(fallback WordSet) {
+	filled := false
+	for _, key := range Categories() {
+		if w.defined[key] || !fallback.defined[key] {
+			continue
+		}
+		list := fallback.Get(key)
+		w.set(key, list)
+		w.defined[key] = true
+		filled = true
+
+		if w.origins == nil {
+			w.origins = make(map[originKey]Origin)
+		}
+		for _, word := range list {
+			w.origins[originKey{key, word}] = fallback.Origin(key, word)
+			if f, ok := fallback.grammar[word]; ok {
+				if _, own := w.grammar[word]; !own {
+					if w.grammar == nil {
+						w.grammar = make(map[string]Features)
+					}
+					w.grammar[word] = f
+				}
+			}
+		}
+	}
+	if filled {
+		w.Fallback = append(w.Fallback, fallback.Chain[0])
+	}
+}
+
+// complete reports whether every category is declared.
+func (w WordSet) complete() bool {
+	for _, key := range Categories() {
+		if !w.defined[key] {
+			return false
+		}
+	}
+	return true
+}
+
+// isDir reports whether path is an existing directory.
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// set replaces the word list for a category key.
+// Unknown keys are ignored; callers validate keys against Categories.
+func (w *WordSet) set(key string, list []string) {
+	switch key {
+	case "adjectives":
+		w.Adjectives = list
+	case "buzzwords":
+		w.Buzzwords = list
+	case "core":
+		w.Core = list
+	case "suffix":
+		w.Suffix = list
+	case "animals":
+		w.Animals = list
+	case "colors":
+		w.Colors = list
+	case "celestial":
+		w.Celestial = list
+	case "scientists":
+		w.Scientists = list
+	case "places":
+		w.Places = list
+	}
+}
@@ -3,6 +3,7 @@ package words
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -46,7 +47,7 @@ func TestLoad_EnglishMinimal(t *testing.T) {
 func TestLoad_AllLanguagesAndModes(t *testing.T) {
 	chdirToRoot(t)
 
-	langs := []string{"en", "de"}
+	langs := []string{"en", "de", "fr", "es", "nl", "it"}
 	modes := []string{"minimal", "startup", "enterprise", "bullshit"}
 
 	for _, lang := range langs {
@@ -155,3 +156,70 @@ func TestGet_UnknownKey(t *testing.T) {
 		t.Errorf("Get(unknown) = %v, want nil", got)
 	}
 }
+
+func TestLoad_RequiredCategoriesPresent(t *testing.T) {
+	chdirToRoot(t)
+
+	// Minimal files list buzzwords and suffix as empty arrays, which is fine
+	if _, err := Load("en", "minimal", "adjectives", "buzzwords", "core", "suffix"); err != nil {
+		t.Errorf("Load(en, minimal) with all categories required: %v", err)
+	}
+}
+
+func TestDecode_Strict(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		required []string
+		want     string
+	}{
+		{
+			name:    "unknown field",
+			content: "{\n  \"adjectivs\": [\"Smart\"]\n}",
+			want:    `test.json:2:3: unknown category "adjectivs"`,
+		},
+		{
+			name:    "trailing data",
+			content: "{\"core\": [\"Engine\"]}\n{\"core\": []}",
+			want:    "test.json:2:1: unexpected data after the top-level object",
+		},
+		{
+			name:    "syntax error",
+			content: "{\n  \"core\": [\"Engine\" \"Hub\"]\n}",
+			want:    "test.json:2:22: invalid JSON",
+		},
+		{
+			name:    "wrong type",
+			content: `{"core": [1]}`,
+			want:    `test.json:1:11: category "core" must only contain strings`,
+		},
+		{
+			name:     "missing required categories",
+			content:  `{"adjectives": ["Smart"]}`,
+			required: []string{"adjectives", "core", "suffix"},
+			want:     "test.json: missing required categories: core, suffix",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decode("test.json", "en", "startup", []byte(tt.content), tt.required)
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error %q does not contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecode_Valid(t *testing.T) {
+	ws, err := decode("test.json", "en", "startup", []byte(`{"adjectives": ["Smart"], "core": ["Engine", "Hub"]}`), []string{"adjectives", "core"})
+	if err != nil {
+		t.Fatalf("decode error: %v", err)
+	}
+	if len(ws.Adjectives) != 1 || len(ws.Core) != 2 || ws.Buzzwords != nil {
+		t.Errorf("unexpected word set %+v", ws)
+	}
+}
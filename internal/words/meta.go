@@ -0,0 +1,82 @@
+package words
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// SchemaVersion is the newest word file schema this loader understands.
+// Files without a header are treated as version 1. Version 2 added the
+// optional grammar section.
+const SchemaVersion = 2
+
+// metaKey is the top-level key of the optional header in a word file.
+const metaKey = "meta"
+
+// Meta is the optional header of a word file:
+//
+//	"meta": {
+//	  "schema_version": 1,
+//	  "name": "English Startup",
+//	  "description": "Balanced startup-style names",
+//	  "language": "en",
+//	  "license": "MIT",
+//	  "extends": "en/enterprise"
+//	}
+type Meta struct {
+	SchemaVersion int    `json:"schema_version"` // Schema the file was written against (0 = 1)
+	Name          string `json:"name"`           // Human-readable pack name (defaults to "{lang}/{mode}" or the file name)
+	Description   string `json:"description"`    // One-line summary shown by `list`
+	Language      string `json:"language"`       // Language code; must match the directory if set
+	License       string `json:"license"`        // License of the word list (e.g. "MIT")
+	Extends       string `json:"extends"`        // Parent pack as "{lang}/{mode}" or a relative path to a .json file
+}
+
+// Origin records which pack supplied a word.
+type Origin struct {
+	Pack          string // Pack name from the header
+	SchemaVersion int    // Schema version of that pack
+	Lang          string // Language of that pack
+}
+
+// String formats the origin for explain output, e.g. "English Startup (en, schema v1)".
+func (o Origin) String() string {
+	return fmt.Sprintf("%s (%s, schema v%d)", o.Pack, o.Lang, o.SchemaVersion)
+}
+
+// extendsPattern matches a pack reference of the form "{lang}/{mode}".
+var extendsPattern = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{1,8})*/[a-z0-9_-]+$`)
+
+// validate checks the header against the file's location and fills in
+// defaults. lang and mode come from the file path; either may be empty
+// when the path does not follow the data/{lang}/{mode}.json layout.
+func (m *Meta) validate(lang, mode string) error {
+	switch {
+	case m.SchemaVersion < 0:
+		return fmt.Errorf("%s.schema_version must not be negative", metaKey)
+	case m.SchemaVersion > SchemaVersion:
+		return fmt.Errorf("%s.schema_version %d is newer than supported version %d", metaKey, m.SchemaVersion, SchemaVersion)
+	case m.SchemaVersion == 0:
+		m.SchemaVersion = 1
+	}
+
+	if m.Language != "" && lang != "" && m.Language != lang {
+		return fmt.Errorf("%s.language %q does not match directory %q", metaKey, m.Language, lang)
+	}
+	if m.Language == "" {
+		m.Language = lang
+	}
+
+	if m.Extends != "" && !strings.HasSuffix(m.Extends, ".json") && !extendsPattern.MatchString(m.Extends) {
+		return fmt.Errorf("%s.extends %q must have the form {lang}/{mode} or name a .json file", metaKey, m.Extends)
+	}
+
+	if m.Name == "" {
+		m.Name = mode
+		if lang != "" {
+			m.Name = lang + "/" + mode
+		}
+	}
+	return nil
+}
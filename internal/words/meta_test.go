@@ -0,0 +1,93 @@
+package words
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMeta_ValidateDefaults(t *testing.T) {
+	var m Meta
+	if err := m.validate("en", "startup"); err != nil {
+		t.Fatalf("validate error: %v", err)
+	}
+
+	if m.SchemaVersion != 1 {
+		t.Errorf("SchemaVersion = %d, want 1", m.SchemaVersion)
+	}
+	if m.Name != "en/startup" {
+		t.Errorf("Name = %q, want %q", m.Name, "en/startup")
+	}
+	if m.Language != "en" {
+		t.Errorf("Language = %q, want %q", m.Language, "en")
+	}
+}
+
+func TestMeta_ValidateErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		meta Meta
+		want string
+	}{
+		{"newer schema", Meta{SchemaVersion: SchemaVersion + 1}, "newer than supported"},
+		{"negative schema", Meta{SchemaVersion: -1}, "must not be negative"},
+		{"language mismatch", Meta{Language: "de"}, `does not match directory "en"`},
+		{"malformed extends", Meta{Extends: "enterprise"}, "must have the form {lang}/{mode}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.meta.validate("en", "startup")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("validate() = %v, want error containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestMeta_ValidExtends(t *testing.T) {
+	m := Meta{Extends: "en/enterprise"}
+	if err := m.validate("en", "internal"); err != nil {
+		t.Errorf("validate error: %v", err)
+	}
+}
+
+func TestDecode_Meta(t *testing.T) {
+	content := `{
+  "meta": {"schema_version": 1, "name": "Test Pack", "license": "MIT"},
+  "core": ["Engine"]
+}`
+	ws, err := decode("test.json", "en", "startup", []byte(content), nil)
+	if err != nil {
+		t.Fatalf("decode error: %v", err)
+	}
+
+	if ws.Meta.Name != "Test Pack" || ws.Meta.License != "MIT" || ws.Meta.Language != "en" {
+		t.Errorf("unexpected meta %+v", ws.Meta)
+	}
+	o := ws.Origin("core", "Engine")
+	if o.Pack != "Test Pack" || o.SchemaVersion != 1 || o.Lang != "en" {
+		t.Errorf("unexpected origin %+v", o)
+	}
+}
+
+func TestDecode_MetaErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"unknown field", "{\n  \"meta\": {\"version\": 1}\n}", `test.json:2:11: meta: unknown field "version"`},
+		{"wrong type", `{"meta": {"schema_version": "1"}}`, "meta.schema_version must be a int"},
+		{"unsupported version", "{\n  \"meta\": {\"schema_version\": 9}\n}", "test.json:2:11: meta.schema_version 9 is newer"},
+		{"defined twice", `{"meta": {}, "meta": {}}`, `"meta" defined twice`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decode("test.json", "en", "startup", []byte(tt.content), nil)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("decode() = %v, want error containing %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+package words
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// langDirPattern matches directory names that look like language codes.
+var langDirPattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Za-z0-9]{1,8})*$`)
+
+// resolve builds the effective WordSet of a pack from its file contents.
+//
+// If the header names a parent in "extends", the parent is resolved first
+// and this pack's categories are applied on top of it:
+//
+//   - a category that is absent is inherited unchanged
+//   - a plain array replaces the inherited list
+//   - an object applies its operations to the inherited list, in the order
+//     replace, remove, add (add skips words that are already present)
+//
+// Grammar entries are merged per word; an entry in this pack replaces the
+// inherited entry for the same word.
+//
+// stack holds the packs currently being resolved, from the pack that was
+// requested down to this one's child, to detect cycles.
+func resolve(path, lang, mode string, data []byte, stack []packFrame) (WordSet, error) {
+	// Scan the token stream so errors can be reported with positions
+	file, err := scanFile(data)
+	if err != nil {
+		var se *schemaError
+		if errors.As(err, &se) {
+			line, col := position(data, se.offset)
+			return WordSet{}, fmt.Errorf("%s:%d:%d: %s", path, line, col, se.msg)
+		}
+		return WordSet{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	// Check the header and fill in its defaults (name, language, version)
+	meta := file.meta
+	if err := meta.validate(lang, mode); err != nil {
+		line, col := position(data, file.metaOffset)
+		return WordSet{}, fmt.Errorf("%s:%d:%d: %w", path, line, col, err)
+	}
+	if err := validateGrammar(file.grammar, meta); err != nil {
+		line, col := position(data, file.grammarOffset)
+		return WordSet{}, fmt.Errorf("%s:%d:%d: %w", path, line, col, err)
+	}
+
+	self := newPackFrame(path, lang, mode)
+	chain := append(slices.Clip(stack), self)
+
+	// Resolve the parent first; its lists are the base for this pack.
+	// Cycles are detected on the files, as an overlay kept in a
+	// {lang}/{mode}.json layout of its own shares its parent's id
+	var base WordSet
+	if meta.Extends != "" {
+		parentPath, parentLang, parentMode := parentRef(path, meta.Extends)
+		parent := newPackFrame(parentPath, parentLang, parentMode)
+		if i := slices.IndexFunc(chain, func(f packFrame) bool { return f.file == parent.file }); i >= 0 {
+			var cycle []string
+			for _, f := range append(slices.Clone(chain[i:]), parent) {
+				cycle = append(cycle, f.id)
+			}
+			return WordSet{}, fmt.Errorf("%s: extends cycle: %s", path, strings.Join(cycle, " → "))
+		}
+
+		parentData, err := os.ReadFile(parentPath)
+		if err != nil {
+			return WordSet{}, fmt.Errorf("%s: extends %q: %w", path, meta.Extends, err)
+		}
+		if base, err = resolve(parentPath, parentLang, parentMode, parentData, chain); err != nil {
+			return WordSet{}, fmt.Errorf("%s: extends %q: %w", path, meta.Extends, err)
+		}
+	}
+
+	origin := Origin{Pack: meta.Name, SchemaVersion: meta.SchemaVersion, Lang: meta.Language}
+	ws := WordSet{
+		Meta:    meta,
+		Chain:   append([]string{self.id}, base.Chain...),
+		defined: make(map[string]bool),
+	}
+	if meta.Extends != "" {
+		ws.origins = make(map[originKey]Origin)
+	}
+
+	// Grammar entries merge per word, this pack's entries winning
+	if len(base.grammar)+len(file.grammar) > 0 {
+		ws.grammar = maps.Clone(base.grammar)
+		if ws.grammar == nil {
+			ws.grammar = make(map[string]Features, len(file.grammar))
+		}
+		maps.Copy(ws.grammar, file.grammar)
+	}
+
+	for _, key := range Categories() {
+		inherited := base.Get(key)
+		c, declared := file.categories[key]
+
+		var list []string
+		switch {
+		case !declared:
+			// Inherit the parent's list as is
+			list = inherited
+			ws.defined[key] = base.defined[key]
+		case c.overlay == nil:
+			// A plain array defines the list outright
+			list = words(c.entries)
+			ws.defined[key] = true
+		case meta.Extends == "":
+			return WordSet{}, fmt.Errorf("%s:%d:%d: category %q uses add/remove/replace but the pack does not extend another pack", path, c.line, c.col, key)
+		default:
+			list = applyOverlay(inherited, c.overlay)
+			ws.defined[key] = true
+		}
+		ws.set(key, list)
+
+		// Words this pack introduced carry its own origin; the rest keep
+		// whatever origin they had in the parent
+		if ws.origins == nil {
+			continue
+		}
+		own := ownWords(c, declared)
+		for _, w := range list {
+			if own[w] {
+				ws.origins[originKey{key, w}] = origin
+			} else {
+				ws.origins[originKey{key, w}] = base.Origin(key, w)
+			}
+		}
+	}
+	return ws, nil
+}
+
+// applyOverlay applies an overlay's operations to an inherited list and
+// returns the effective list. The inherited list is not modified.
+func applyOverlay(inherited []string, o *scannedOverlay) []string {
+	list := slices.Clone(inherited)
+	if o.hasReplace {
+		list = words(o.replace)
+	}
+
+	if len(o.remove) > 0 {
+		drop := make(map[string]bool, len(o.remove))
+		for _, e := range o.remove {
+			drop[e.word] = true
+		}
+		list = slices.DeleteFunc(list, func(w string) bool { return drop[w] })
+	}
+
+	for _, e := range o.add {
+		if !slices.Contains(list, e.word) {
+			list = append(list, e.word)
+		}
+	}
+	if list == nil {
+		list = []string{} // Declared but empty, like an explicit []
+	}
+	return list
+}
+
+// ownWords returns the set of words a pack itself contributes to a
+// category (see scannedCategory.ownEntries).
+func ownWords(c scannedCategory, declared bool) map[string]bool {
+	own := make(map[string]bool)
+	if declared {
+		for _, e := range c.ownEntries() {
+			own[e.word] = true
+		}
+	}
+	return own
+}
+
+// words extracts the word strings from scanned entries. The result is
+// never nil, so a declared empty array stays distinguishable from an
+// absent category.
+func words(entries []entry) []string {
+	list := make([]string, len(entries))
+	for i, e := range entries {
+		list[i] = e.word
+	}
+	return list
+}
+
+// parentRef locates the pack named by an extends reference. A reference
+// ending in ".json" is a path relative to the child file; anything else is
+// a bundled pack "{lang}/{mode}" in DataDir.
+func parentRef(childPath, extends string) (path, lang, mode string) {
+	if strings.HasSuffix(extends, ".json") {
+		path = extends
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(filepath.Dir(childPath), path)
+		}
+		lang, mode = packIdentity(path)
+		return path, lang, mode
+	}
+
+	lang, mode, _ = strings.Cut(extends, "/")
+	return filepath.Join(DataDir, lang, mode+".json"), lang, mode
+}
+
+// packIdentity derives language and mode from a word file path following
+// the {lang}/{mode}.json layout. lang is empty if the directory does not
+// look like a language code.
+func packIdentity(path string) (lang, mode string) {
+	mode = strings.TrimSuffix(filepath.Base(path), ".json")
+	lang = filepath.Base(filepath.Dir(path))
+	if !langDirPattern.MatchString(lang) {
+		lang = ""
+	}
+	return lang, mode
+}
+
+// packFrame is a pack on the stack of resolve.
+type packFrame struct {
+	file string // Cleaned absolute path, identifying the pack
+	id   string // Name for chains and messages (see packID)
+}
+
+// newPackFrame returns the frame of the pack stored at path.
+func newPackFrame(path, lang, mode string) packFrame {
+	file, err := filepath.Abs(path)
+	if err != nil {
+		file = filepath.Clean(path)
+	}
+	return packFrame{file: file, id: packID(path, lang, mode)}
+}
+
+// packID names a pack in chains and error messages: "{lang}/{mode}" when
+// the language is known, the file path otherwise.
+func packID(path, lang, mode string) string {
+	if lang == "" {
+		return path
+	}
+	return lang + "/" + mode
+}
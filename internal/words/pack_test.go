@@ -0,0 +1,211 @@
+package words
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestLoadFile_Overlays(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "base.json", `{
+  "meta": {"name": "Base"},
+  "adjectives": ["Smart", "Fast"],
+  "core": ["Data", "Flow", "Sync"],
+  "suffix": ["Hub"]
+}`)
+	path := writeFile(t, dir, "child.json", `{
+  "meta": {"name": "Child", "extends": "base.json"},
+  "adjectives": ["Bold"],
+  "core": {"add": ["Ledger", "Data"], "remove": ["Flow"]},
+  "suffix": {"replace": ["Engine"]}
+}`)
+
+	ws, err := LoadFile(path, "adjectives", "core", "suffix")
+	if err != nil {
+		t.Fatalf("LoadFile error: %v", err)
+	}
+
+	tests := []struct {
+		key  string
+		want []string
+	}{
+		{"adjectives", []string{"Bold"}},             // plain array replaces
+		{"buzzwords", nil},                           // never declared
+		{"core", []string{"Data", "Sync", "Ledger"}}, // remove, then add without duplicates
+		{"suffix", []string{"Engine"}},               // replace
+	}
+	for _, tt := range tests {
+		if got := ws.Get(tt.key); !slices.Equal(got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.key, got, tt.want)
+		}
+	}
+
+	if want := []string{path, filepath.Join(dir, "base.json")}; !slices.Equal(ws.Chain, want) {
+		t.Errorf("Chain = %v, want %v", ws.Chain, want)
+	}
+}
+
+func TestLoadFile_Origins(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "base.json", `{"meta": {"name": "Base"}, "core": ["Data"]}`)
+	writeFile(t, dir, "middle.json", `{"meta": {"name": "Middle", "extends": "base.json"}, "core": {"add": ["Flow"]}}`)
+	path := writeFile(t, dir, "top.json", `{"meta": {"name": "Top", "extends": "middle.json"}, "core": {"add": ["Sync"]}}`)
+
+	ws, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile error: %v", err)
+	}
+
+	for word, pack := range map[string]string{"Data": "Base", "Flow": "Middle", "Sync": "Top"} {
+		if got := ws.Origin("core", word).Pack; got != pack {
+			t.Errorf("Origin(core, %s) = %q, want %q", word, got, pack)
+		}
+	}
+	if len(ws.Chain) != 3 {
+		t.Errorf("Chain = %v, want 3 packs", ws.Chain)
+	}
+}
+
+func TestLoadFile_ExtendsBundledPack(t *testing.T) {
+	chdirToRoot(t)
+
+	parent, err := Load("en", "enterprise")
+	if err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+
+	path := writeFile(t, t.TempDir(), "team.json", `{
+  "meta": {"name": "Team", "extends": "en/enterprise"},
+  "core": {"add": ["Ledger"]}
+}`)
+	ws, err := LoadFile(path, "adjectives", "buzzwords", "core", "suffix")
+	if err != nil {
+		t.Fatalf("LoadFile error: %v", err)
+	}
+
+	if !slices.Equal(ws.Adjectives, parent.Adjectives) {
+		t.Error("adjectives should be inherited unchanged")
+	}
+	if want := append(slices.Clone(parent.Core), "Ledger"); !slices.Equal(ws.Core, want) {
+		t.Errorf("core = %v, want %v", ws.Core, want)
+	}
+	if got := ws.Origin("adjectives", ws.Adjectives[0]).Pack; got != parent.Meta.Name {
+		t.Errorf("inherited origin = %q, want %q", got, parent.Meta.Name)
+	}
+	if ws.Chain[len(ws.Chain)-1] != "en/enterprise" {
+		t.Errorf("Chain = %v, want en/enterprise at the root", ws.Chain)
+	}
+}
+
+func TestLoadFile_OverlayInLangLayout(t *testing.T) {
+	chdirToRoot(t)
+
+	// An overlay kept in a {lang}/{mode}.json layout outside the bundled
+	// data has the same id as the pack it extends, but is no cycle
+	path := writeFile(t, t.TempDir(), filepath.Join("en", "enterprise.json"), `{
+  "meta": {"name": "Team", "extends": "en/enterprise"},
+  "core": {"add": ["Ledger"]}
+}`)
+	ws, err := LoadFile(path, "adjectives", "buzzwords", "core", "suffix")
+	if err != nil {
+		t.Fatalf("LoadFile error: %v", err)
+	}
+	if !slices.Contains(ws.Core, "Ledger") {
+		t.Errorf("core = %v, want the overlay's Ledger", ws.Core)
+	}
+	if want := []string{"en/enterprise", "en/enterprise"}; !slices.Equal(ws.Chain, want) {
+		t.Errorf("Chain = %v, want %v", ws.Chain, want)
+	}
+	issues, err := Validate(path, nil)
+	if err != nil {
+		t.Fatalf("Validate error: %v", err)
+	}
+	for _, issue := range issues {
+		if issue.Severity == Error {
+			t.Errorf("validate: %v", issue)
+		}
+	}
+}
+
+func TestLoadFile_InheritanceErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string // extra files next to the pack
+		content string
+		want    string
+	}{
+		{
+			name:    "cycle",
+			files:   map[string]string{"b.json": `{"meta": {"extends": "pack.json"}}`},
+			content: `{"meta": {"extends": "b.json"}}`,
+			want:    "extends cycle",
+		},
+		{
+			name:    "self",
+			content: `{"meta": {"extends": "pack.json"}}`,
+			want:    "extends cycle",
+		},
+		{
+			name:    "missing parent",
+			content: `{"meta": {"extends": "nope.json"}}`,
+			want:    `extends "nope.json"`,
+		},
+		{
+			name:    "overlay without extends",
+			content: `{"core": {"add": ["Data"]}}`,
+			want:    "does not extend another pack",
+		},
+		{
+			name:    "replace with add",
+			files:   map[string]string{"b.json": `{"core": ["Data"]}`},
+			content: `{"meta": {"extends": "b.json"}, "core": {"replace": ["A"], "add": ["B"]}}`,
+			want:    "cannot combine replace",
+		},
+		{
+			name:    "unknown operation",
+			files:   map[string]string{"b.json": `{"core": ["Data"]}`},
+			content: `{"meta": {"extends": "b.json"}, "core": {"append": ["A"]}}`,
+			want:    `unknown operation "append"`,
+		},
+		{
+			name:    "broken parent",
+			files:   map[string]string{"b.json": `{"core": [1]}`},
+			content: `{"meta": {"extends": "b.json"}}`,
+			want:    "must only contain strings",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				writeFile(t, dir, name, content)
+			}
+			path := writeFile(t, dir, "pack.json", tt.content)
+
+			_, err := LoadFile(path)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error %q does not mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadFile_InheritedRequiredCategories(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "base.json", `{"adjectives": ["Smart"], "core": ["Data"]}`)
+	path := writeFile(t, dir, "child.json", `{"meta": {"extends": "base.json"}, "core": {"add": ["Flow"]}}`)
+
+	if _, err := LoadFile(path, "adjectives", "core"); err != nil {
+		t.Errorf("inherited categories should satisfy the pattern: %v", err)
+	}
+	_, err := LoadFile(path, "adjectives", "suffix")
+	if err == nil || !strings.Contains(err.Error(), "missing required categories: suffix") {
+		t.Errorf("expected missing suffix, got %v", err)
+	}
+}
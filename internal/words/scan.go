@@ -0,0 +1,275 @@
+package words
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// entry is a word together with where it was found.
+type entry struct {
+	word      string
+	line, col int
+}
+
+// scannedCategory is a category value and its entries with positions.
+// A plain array defines the list outright; an object is an overlay on the
+// list inherited from the parent pack.
+type scannedCategory struct {
+	line, col int
+	entries   []entry // Words of a plain array
+	overlay   *scannedOverlay
+}
+
+// scannedOverlay holds the operations of an overlay category object.
+type scannedOverlay struct {
+	add, remove, replace []entry
+	hasReplace           bool // replace was given (possibly empty)
+}
+
+// ownEntries returns the words the category itself contributes: the
+// entries of a plain array, or an overlay's replace and add lists.
+func (c scannedCategory) ownEntries() []entry {
+	if c.overlay == nil {
+		return c.entries
+	}
+	return append(slices.Clone(c.overlay.replace), c.overlay.add...)
+}
+
+// overlayOps are the keys allowed in an overlay category object.
+var overlayOps = []string{"add", "remove", "replace"}
+
+// scannedFile is the positional view of a word file shared by the loader
+// and the linter.
+type scannedFile struct {
+	meta       Meta                       // Optional header (zero value if absent)
+	hasMeta    bool                       // Whether the file declared a header
+	metaOffset int64                      // Byte offset of the header object
+	categories map[string]scannedCategory // Category values by key
+
+	grammar       map[string]Features // Optional grammar section (nil if absent)
+	hasGrammar    bool                // Whether the file declared a grammar section
+	grammarOffset int64               // Byte offset of the grammar object
+}
+
+// schemaError is a structural problem at a byte offset in the file.
+type schemaError struct {
+	offset int64
+	msg    string
+}
+
+func (e *schemaError) Error() string { return e.msg }
+
+// scanFile walks the JSON token stream of a word file and returns the
+// header and every category with the position of each word. Unlike
+// json.Unmarshal it keeps the source positions, which both the strict
+// loader and the linter need for their messages.
+func scanFile(data []byte) (scannedFile, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	// next reads a token and remembers the byte offset it started at
+	var start int64
+	next := func() (json.Token, error) {
+		start = tokenStart(data, dec.InputOffset())
+		tok, err := dec.Token()
+		var se *json.SyntaxError
+		if errors.As(err, &se) {
+			return nil, &schemaError{se.Offset, "invalid JSON: " + se.Error()}
+		}
+		return tok, err
+	}
+
+	// scanStrings reads the elements of an array whose '[' was just consumed,
+	// including the closing ']'; label names the array in error messages
+	scanStrings := func(label string) ([]entry, error) {
+		var entries []entry
+		for dec.More() {
+			tok, err := next()
+			if err != nil {
+				return nil, err
+			}
+			word, ok := tok.(string)
+			if !ok {
+				return nil, &schemaError{start, label + " must only contain strings"}
+			}
+			line, col := position(data, start)
+			entries = append(entries, entry{word: word, line: line, col: col})
+		}
+		if _, err := next(); err != nil { // Closing ']'
+			return nil, err
+		}
+		return entries, nil
+	}
+
+	file := scannedFile{categories: make(map[string]scannedCategory)}
+
+	tok, err := next()
+	if err != nil {
+		return scannedFile{}, err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '{' {
+		return scannedFile{}, &schemaError{start, "word file must be a JSON object"}
+	}
+
+	for dec.More() {
+		tok, err := next()
+		if err != nil {
+			return scannedFile{}, err
+		}
+		key := tok.(string) // Object keys are always strings
+		keyStart := start
+
+		// The optional header is decoded as a whole
+		if key == metaKey {
+			if file.hasMeta {
+				return scannedFile{}, &schemaError{keyStart, fmt.Sprintf("%q defined twice", metaKey)}
+			}
+			file.metaOffset = tokenStart(data, dec.InputOffset())
+			if err := decodeSection(dec, file.metaOffset, metaKey, &file.meta); err != nil {
+				return scannedFile{}, err
+			}
+			file.hasMeta = true
+			continue
+		}
+
+		// So is the grammar section
+		if key == grammarKey {
+			if file.hasGrammar {
+				return scannedFile{}, &schemaError{keyStart, fmt.Sprintf("%q defined twice", grammarKey)}
+			}
+			file.grammarOffset = tokenStart(data, dec.InputOffset())
+			if err := decodeSection(dec, file.grammarOffset, grammarKey, &file.grammar); err != nil {
+				return scannedFile{}, err
+			}
+			file.hasGrammar = true
+			continue
+		}
+
+		if !slices.Contains(Categories(), key) {
+			return scannedFile{}, &schemaError{keyStart, fmt.Sprintf("unknown category %q (valid: %v)", key, Categories())}
+		}
+		if _, dup := file.categories[key]; dup {
+			return scannedFile{}, &schemaError{keyStart, fmt.Sprintf("category %q defined twice", key)}
+		}
+
+		tok, err = next()
+		if err != nil {
+			return scannedFile{}, err
+		}
+
+		line, col := position(data, keyStart)
+		c := scannedCategory{line: line, col: col}
+		switch tok {
+		case json.Delim('['):
+			if c.entries, err = scanStrings(fmt.Sprintf("category %q", key)); err != nil {
+				return scannedFile{}, err
+			}
+		case json.Delim('{'):
+			c.overlay = &scannedOverlay{}
+			seen := make(map[string]bool)
+			for dec.More() {
+				tok, err := next()
+				if err != nil {
+					return scannedFile{}, err
+				}
+				op := tok.(string)
+				if !slices.Contains(overlayOps, op) {
+					return scannedFile{}, &schemaError{start, fmt.Sprintf("unknown operation %q in category %q (valid: %v)", op, key, overlayOps)}
+				}
+				if seen[op] {
+					return scannedFile{}, &schemaError{start, fmt.Sprintf("operation %s.%s defined twice", key, op)}
+				}
+				seen[op] = true
+				if tok, err = next(); err != nil {
+					return scannedFile{}, err
+				}
+				if tok != json.Delim('[') {
+					return scannedFile{}, &schemaError{start, fmt.Sprintf("%s.%s must be an array of strings", key, op)}
+				}
+				list, err := scanStrings(key + "." + op)
+				if err != nil {
+					return scannedFile{}, err
+				}
+				switch op {
+				case "add":
+					c.overlay.add = list
+				case "remove":
+					c.overlay.remove = list
+				case "replace":
+					c.overlay.replace, c.overlay.hasReplace = list, true
+				}
+			}
+			if _, err := next(); err != nil { // Closing '}'
+				return scannedFile{}, err
+			}
+			// replace defines the whole list, so editing it in the same breath is ambiguous
+			if c.overlay.hasReplace && (seen["add"] || seen["remove"]) {
+				return scannedFile{}, &schemaError{keyStart, fmt.Sprintf("category %q cannot combine replace with add or remove", key)}
+			}
+		default:
+			return scannedFile{}, &schemaError{start, fmt.Sprintf("category %q must be an array of strings or an add/remove/replace object", key)}
+		}
+		file.categories[key] = c
+	}
+	if _, err := next(); err != nil { // Closing '}'
+		return scannedFile{}, err
+	}
+
+	// Nothing but whitespace may follow the object
+	if _, err := next(); err != io.EOF {
+		return scannedFile{}, &schemaError{start, "unexpected data after the top-level object"}
+	}
+	return file, nil
+}
+
+// decodeSection decodes the object of a top-level section such as the
+// header at the decoder's position into v, translating decoding errors
+// into positioned schema errors. key names the section in messages.
+func decodeSection(dec *json.Decoder, offset int64, key string, v any) error {
+	err := dec.Decode(v)
+	if err == nil {
+		return nil
+	}
+
+	var se *json.SyntaxError
+	var te *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &se):
+		return &schemaError{se.Offset, "invalid JSON: " + se.Error()}
+	case errors.As(err, &te):
+		return &schemaError{te.Offset, fmt.Sprintf("%s.%s must be a %s", key, te.Field, te.Type)}
+	default:
+		// Unknown fields carry no offset; point at the section itself
+		return &schemaError{offset, key + ": " + strings.TrimPrefix(err.Error(), "json: ")}
+	}
+}
+
+// tokenStart skips the whitespace and separators the decoder has not yet
+// consumed, returning the offset at which the next token begins.
+func tokenStart(data []byte, offset int64) int64 {
+	for offset < int64(len(data)) {
+		switch data[offset] {
+		case ' ', '\t', '\r', '\n', ',', ':':
+			offset++
+		default:
+			return offset
+		}
+	}
+	return offset
+}
+
+// position converts a byte offset into a 1-based line and column.
+// Columns count runes, so non-ASCII words do not shift the column.
+func position(data []byte, offset int64) (line, col int) {
+	offset = min(offset, int64(len(data)))
+	before := data[:offset]
+	line = bytes.Count(before, []byte{'\n'}) + 1
+	lineStart := bytes.LastIndexByte(before, '\n') + 1
+	col = len([]rune(string(before[lineStart:]))) + 1
+	return line, col
+}
@@ -0,0 +1,329 @@
+package words
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+type Severity int
+
+const (
+	Warning Severity = iota // Suspicious but loadable (e.g. a word shared by two categories)
+	Error                   // Broken data that should not ship
+)
+
+func (s Severity) String() string {
+	if s == Error {
+		return "error"
+	}
+	return "warning"
+}
+
+type Issue struct {
+	File     string   // Path of the word file
+	Line     int      // 1-based line of the offending token (0 if not applicable)
+	Col      int      // 1-based column of the offending token
+	Severity Severity // Error or Warning
+	Message  string   // Human-readable description
+}
+
+// String formats the issue in the usual file:line:col style understood by
+// editors and CI annotations.
+func (i Issue) String() string {
+	if i.Line == 0 {
+		return fmt.Sprintf("%s: %s: %s", i.File, i.Severity, i.Message)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s: %s", i.File, i.Line, i.Col, i.Severity, i.Message)
+}
+
+// Validate lints a word file, or every *.json file below a directory.
+//
+// The patterns map is keyed by mode name (the file's base name without
+// extension, e.g. "startup") and lists the categories that mode's pattern
+// draws from; those categories must not be empty. Files for modes that are
+// not in the map skip that check.
+//
+// Checks performed on every file:
+//   - schema: a JSON object of known categories holding arrays of strings,
+//     plus an optional "meta" header with a supported schema_version
+//   - empty strings, leading/trailing or repeated whitespace
+//   - forbidden characters (anything but letters, digits, marks, space and -'.&)
+//   - casing: words start with an upper-case letter or a digit
+//   - duplicates within a category, including words that only differ in case
+//   - duplicates across categories (warning: shared nouns are sometimes intended)
+//   - overlays: the parent must resolve, removed words should be inherited
+//     and added words should not be (warnings)
+//   - grammar: known genders, numbers, parts of speech and forms; entries
+//     should match a word of the pack, and nouns should have a gender once
+//     the pack has grammar (warnings)
+//
+// The returned error is reserved for I/O failures; problems with the data
+// itself are reported as issues, sorted by file and position.
+func Validate(path string, patterns map[string][]string) ([]Issue, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	if info.IsDir() {
+		err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() && filepath.Ext(p) == ".json" {
+				files = append(files, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		files = []string{path}
+	}
+
+	var issues []Issue
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		lang, mode := packIdentity(file)
+		issues = append(issues, validateData(file, lang, mode, data, patterns[mode])...)
+	}
+	return issues, nil
+}
+
+// occurrence is an entry and the category it was found in.
+type occurrence struct {
+	category string
+	entry
+}
+
+// validateData lints the contents of a single word file. lang and mode
+// identify the pack (lang may be empty); required lists the categories
+// that must be present and non-empty.
+func validateData(file, lang, mode string, data []byte, required []string) []Issue {
+	var issues []Issue
+	report := func(sev Severity, line, col int, format string, args ...any) {
+		issues = append(issues, Issue{
+			File:     file,
+			Line:     line,
+			Col:      col,
+			Severity: sev,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	scanned, err := scanFile(data)
+	if err != nil {
+		var se *schemaError
+		if errors.As(err, &se) {
+			line, col := position(data, se.offset)
+			report(Error, line, col, "%s", se.msg)
+		} else {
+			report(Error, 0, 0, "%v", err)
+		}
+		return issues
+	}
+	categories := scanned.categories
+
+	// Header checks (version, language, extends format)
+	if err := scanned.meta.validate(lang, mode); err != nil {
+		line, col := position(data, scanned.metaOffset)
+		report(Error, line, col, "%v", err)
+	}
+	if err := validateGrammar(scanned.grammar, scanned.meta); err != nil {
+		line, col := position(data, scanned.grammarOffset)
+		report(Error, line, col, "%v", err)
+	}
+
+	// Packs that extend another pack are checked against what they inherit
+	var parent, effective *WordSet
+	if ext := scanned.meta.Extends; ext != "" {
+		parentPath, parentLang, parentMode := parentRef(file, ext)
+		if parentData, err := os.ReadFile(parentPath); err != nil {
+			report(Error, 0, 0, "extends %q: %v", ext, err)
+		} else if ws, err := resolve(parentPath, parentLang, parentMode, parentData, []packFrame{newPackFrame(file, lang, mode)}); err != nil {
+			report(Error, 0, 0, "extends %q: %v", ext, err)
+		} else {
+			parent = &ws
+		}
+		if ws, err := resolve(file, lang, mode, data, nil); err == nil {
+			effective = &ws
+		}
+	}
+
+	// Category presence and emptiness for the mode's pattern, judged on the
+	// effective lists when the pack inherits
+	for _, key := range required {
+		c, declared := categories[key]
+		present, size := declared, len(c.entries)
+		if effective != nil {
+			present, size = effective.defined[key], len(effective.Get(key))
+		}
+		switch {
+		case !present:
+			report(Error, 0, 0, "missing category %q required by the mode's pattern", key)
+		case size == 0:
+			report(Error, c.line, c.col, "category %q is empty but required by the mode's pattern", key)
+		}
+	}
+
+	// Per-word checks and duplicates within each category
+	firstSeen := make(map[string]occurrence) // lower-cased word → first occurrence in any category
+	for _, key := range Categories() {
+		c, ok := categories[key]
+		if !ok {
+			continue
+		}
+
+		// Overlay operations must make sense against the inherited list
+		if c.overlay != nil {
+			if scanned.meta.Extends == "" {
+				report(Error, c.line, c.col, "category %q uses add/remove/replace but the pack does not extend another pack", key)
+			}
+			if parent != nil {
+				inherited := parent.Get(key)
+				for _, e := range c.overlay.remove {
+					if !slices.Contains(inherited, e.word) {
+						report(Warning, e.line, e.col, "removed word %q is not in the inherited %s", e.word, key)
+					}
+				}
+				for _, e := range c.overlay.add {
+					if !c.overlay.hasReplace && slices.Contains(inherited, e.word) {
+						report(Warning, e.line, e.col, "added word %q is already inherited in %s", e.word, key)
+					}
+				}
+			}
+		}
+
+		inCategory := make(map[string]entry)
+		for _, e := range c.ownEntries() {
+			for _, problem := range lintWord(e.word) {
+				report(problem.severity, e.line, e.col, "%s: %q in %s", problem.msg, e.word, key)
+			}
+
+			folded := strings.ToLower(strings.TrimSpace(e.word))
+			if prev, dup := inCategory[folded]; dup {
+				if prev.word == e.word {
+					report(Error, e.line, e.col, "duplicate word %q in %s (first at line %d)", e.word, key, prev.line)
+				} else {
+					report(Error, e.line, e.col, "word %q in %s differs only in case from %q (line %d)", e.word, key, prev.word, prev.line)
+				}
+				continue
+			}
+			inCategory[folded] = e
+
+			if prev, dup := firstSeen[folded]; dup {
+				report(Warning, e.line, e.col, "word %q in %s also appears in %s (line %d)", e.word, key, prev.category, prev.line)
+				continue
+			}
+			firstSeen[folded] = occurrence{category: key, entry: e}
+		}
+	}
+
+	// Grammar entries should describe words of the pack, and once a pack
+	// (or its parent) has grammar, its nouns should have a gender
+	features := scanned.grammar
+	listed := func(word string) bool {
+		for _, c := range categories {
+			if slices.ContainsFunc(c.ownEntries(), func(e entry) bool { return e.word == word }) {
+				return true
+			}
+		}
+		return false
+	}
+	if effective != nil {
+		features = effective.grammar
+		listed = func(word string) bool {
+			return slices.ContainsFunc(Categories(), func(key string) bool { return slices.Contains(effective.Get(key), word) })
+		}
+	}
+	if len(scanned.grammar) > 0 {
+		line, col := position(data, scanned.grammarOffset)
+		for _, word := range slices.Sorted(maps.Keys(scanned.grammar)) {
+			if !listed(word) {
+				report(Warning, line, col, "grammar entry %q matches no word of the pack", word)
+			}
+		}
+	}
+	if len(features) > 0 {
+		for _, key := range NounCategories() {
+			for _, e := range categories[key].ownEntries() {
+				if f := features[e.word]; f.Gender == "" && f.Number != "pl" && f.POS != "adj" {
+					report(Warning, e.line, e.col, "noun %q in %s has no gender in the grammar section", e.word, key)
+				}
+			}
+		}
+	}
+
+	slices.SortStableFunc(issues, func(a, b Issue) int {
+		if a.Line != b.Line {
+			return a.Line - b.Line
+		}
+		return a.Col - b.Col
+	})
+	return issues
+}
+
+// wordProblem is a single finding of lintWord.
+type wordProblem struct {
+	severity Severity
+	msg      string
+}
+
+// lintWord checks a single word for whitespace, character and casing problems.
+func lintWord(word string) []wordProblem {
+	if word == "" {
+		return []wordProblem{{Error, "empty word"}}
+	}
+
+	var problems []wordProblem
+	if strings.TrimSpace(word) != word {
+		problems = append(problems, wordProblem{Error, "leading or trailing whitespace"})
+	}
+	if strings.Contains(word, "  ") {
+		problems = append(problems, wordProblem{Error, "repeated whitespace"})
+	}
+	for _, r := range word {
+		if !allowedRune(r) {
+			problems = append(problems, wordProblem{Error, fmt.Sprintf("forbidden character %q", r)})
+			break
+		}
+	}
+
+	first, _ := firstRune(strings.TrimSpace(word))
+	if unicode.IsLetter(first) && !unicode.IsUpper(first) {
+		problems = append(problems, wordProblem{Warning, "lower-case initial"})
+	}
+	return problems
+}
+
+// allowedRune reports whether r may appear in a word.
+func allowedRune(r rune) bool {
+	switch {
+	case unicode.IsLetter(r), unicode.IsDigit(r), unicode.IsMark(r):
+		return true
+	case r == ' ', r == '-', r == '\'', r == '.', r == '&':
+		return true
+	default:
+		return false
+	}
+}
+
+// firstRune returns the first rune of s and whether s was non-empty.
+func firstRune(s string) (rune, bool) {
+	for _, r := range s {
+		return r, true
+	}
+	return 0, false
+}
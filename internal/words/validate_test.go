@@ -0,0 +1,225 @@
+package words
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var testPatterns = map[string][]string{
+	"minimal": {"adjectives", "core"},
+	"startup": {"adjectives", "core", "suffix"},
+}
+
+// writeFile stores content as name in dir, creating the directories name
+// needs, and returns its path.
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("cannot write %s: %v", name, err)
+	}
+	return path
+}
+
+// findIssue returns the first issue whose message contains substr.
+func findIssue(issues []Issue, substr string) (Issue, bool) {
+	for _, i := range issues {
+		if strings.Contains(i.Message, substr) {
+			return i, true
+		}
+	}
+	return Issue{}, false
+}
+
+func TestValidate_BundledDataHasNoErrors(t *testing.T) {
+	chdirToRoot(t)
+
+	issues, err := Validate(filepath.Join("internal", "words", "data"), testPatterns)
+	if err != nil {
+		t.Fatalf("Validate error: %v", err)
+	}
+	for _, i := range issues {
+		if i.Severity == Error {
+			t.Errorf("unexpected error: %s", i)
+		}
+	}
+}
+
+func TestValidate_Findings(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "startup.json", `{
+  "adjectives": ["Smart", "Smart", " Bold", "fast"],
+  "buzzwords": ["Cloud", "cloud", "", "AI_Ready"],
+  "core": ["Engine", "Data  Lake"],
+  "suffix": ["Engine"]
+}`)
+
+	issues, err := Validate(path, testPatterns)
+	if err != nil {
+		t.Fatalf("Validate error: %v", err)
+	}
+
+	tests := []struct {
+		substr   string
+		line     int
+		col      int
+		severity Severity
+	}{
+		{`duplicate word "Smart" in adjectives`, 2, 27, Error},
+		{`leading or trailing whitespace: " Bold"`, 2, 36, Error},
+		{`lower-case initial: "fast"`, 2, 45, Warning},
+		{`"cloud" in buzzwords differs only in case from "Cloud"`, 3, 26, Error},
+		{`empty word`, 3, 35, Error},
+		{`forbidden character '_'`, 3, 39, Error},
+		{`repeated whitespace: "Data  Lake"`, 4, 22, Error},
+		{`"Engine" in suffix also appears in core`, 5, 14, Warning},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.substr, func(t *testing.T) {
+			i, ok := findIssue(issues, tt.substr)
+			if !ok {
+				t.Fatalf("no issue containing %q in %v", tt.substr, issues)
+			}
+			if i.Line != tt.line || i.Col != tt.col || i.Severity != tt.severity {
+				t.Errorf("got %s, want %d:%d %s", i, tt.line, tt.col, tt.severity)
+			}
+		})
+	}
+}
+
+func TestValidate_RequiredCategories(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "startup.json", `{
+  "adjectives": ["Smart"],
+  "core": []
+}`)
+
+	issues, err := Validate(path, testPatterns)
+	if err != nil {
+		t.Fatalf("Validate error: %v", err)
+	}
+
+	if i, ok := findIssue(issues, `category "core" is empty`); !ok || i.Line != 3 {
+		t.Errorf("expected empty core on line 3, got %v", issues)
+	}
+	if _, ok := findIssue(issues, `missing category "suffix"`); !ok {
+		t.Errorf("expected missing suffix, got %v", issues)
+	}
+}
+
+func TestValidate_UnknownModeSkipsRequiredCheck(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "custom.json", `{"adjectives": ["Smart"]}`)
+
+	issues, err := Validate(path, testPatterns)
+	if err != nil {
+		t.Fatalf("Validate error: %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("expected no issues, got %v", issues)
+	}
+}
+
+func TestValidate_SchemaErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		substr  string
+		line    int
+	}{
+		{"not an object", `["Smart"]`, "must be a JSON object", 1},
+		{"unknown category", "{\n  \"adjectivs\": []\n}", `unknown category "adjectivs"`, 2},
+		{"not an array", `{"core": "Engine"}`, `"core" must be an array`, 1},
+		{"non-string word", "{\"core\": [\n  42\n]}", `"core" must only contain strings`, 2},
+		{"syntax error", "{\n  \"core\": [\"Engine\",]\n}", "invalid JSON", 2},
+		{"truncated", `{"core": ["Engine"`, "unexpected end of JSON input", 1},
+		{"trailing data", `{"core": []} {}`, "unexpected data after", 1},
+		{"duplicate key", `{"core": [], "core": []}`, `"core" defined twice`, 1},
+		{"unsupported header", "{\n  \"meta\": {\"schema_version\": 5}\n}", "newer than supported", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues, err := Validate(writeFile(t, t.TempDir(), "custom.json", tt.content), nil)
+			if err != nil {
+				t.Fatalf("Validate error: %v", err)
+			}
+			i, ok := findIssue(issues, tt.substr)
+			if !ok {
+				t.Fatalf("no issue containing %q in %v", tt.substr, issues)
+			}
+			if i.Line != tt.line || i.Severity != Error {
+				t.Errorf("got %s, want an error on line %d", i, tt.line)
+			}
+		})
+	}
+}
+
+func TestValidate_MissingPath(t *testing.T) {
+	if _, err := Validate(filepath.Join(t.TempDir(), "nope.json"), nil); err == nil {
+		t.Error("expected error for missing path, got nil")
+	}
+}
+
+func TestIssue_String(t *testing.T) {
+	i := Issue{File: "a.json", Line: 3, Col: 7, Severity: Error, Message: "boom"}
+	if got, want := i.String(), "a.json:3:7: error: boom"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestValidate_Overlays(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "base.json", `{"adjectives": ["Smart"], "core": ["Data", "Flow"]}`)
+	path := writeFile(t, dir, "startup.json", `{
+  "meta": {"extends": "base.json"},
+  "core": {"add": ["Data", "ledger"], "remove": ["Sync"]},
+  "suffix": {"replace": ["Hub", "Hub"]}
+}`)
+
+	issues, err := Validate(path, testPatterns)
+	if err != nil {
+		t.Fatalf("Validate error: %v", err)
+	}
+
+	tests := []struct {
+		substr   string
+		line     int
+		severity Severity
+	}{
+		{`added word "Data" is already inherited`, 3, Warning},
+		{`lower-case initial: "ledger"`, 3, Warning},
+		{`removed word "Sync" is not in the inherited core`, 3, Warning},
+		{`duplicate word "Hub" in suffix`, 4, Error},
+	}
+	for _, tt := range tests {
+		i, ok := findIssue(issues, tt.substr)
+		if !ok {
+			t.Errorf("missing issue %q in %v", tt.substr, issues)
+			continue
+		}
+		if i.Line != tt.line || i.Severity != tt.severity {
+			t.Errorf("%q: got line %d %s, want line %d %s", tt.substr, i.Line, i.Severity, tt.line, tt.severity)
+		}
+	}
+
+	// adjectives is inherited, so the required-category check passes
+	if _, ok := findIssue(issues, "missing category"); ok {
+		t.Errorf("inherited categories reported missing: %v", issues)
+	}
+}
+
+func TestValidate_OverlayWithoutParent(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "startup.json", `{"core": {"add": ["Data"]}}`)
+
+	issues, err := Validate(path, nil)
+	if err != nil {
+		t.Fatalf("Validate error: %v", err)
+	}
+	if i, ok := findIssue(issues, "does not extend another pack"); !ok || i.Severity != Error {
+		t.Errorf("expected overlay error, got %v", issues)
+	}
+}